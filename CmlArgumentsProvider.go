@@ -13,14 +13,21 @@ import (
 type cmlArgumentsProvider struct {
 	args     []string
 	switches map[string]string
+	naming   NamingStrategy
+	loaded   bool
+	loadOnce sync.Once
+	lock     sync.Mutex
 }
 
 type cmlArgumentsSource struct {
-	name *string
+	provider *cmlArgumentsProvider
+	name     *string
+	// scoped sources look for variables under the prefix of a scope also by their kebab-case form (see Scope)
+	scoped bool
 }
 
 func (cmlas *cmlArgumentsSource) Provider() Provider {
-	return cmlArgumentsProviderInstance
+	return cmlas.provider
 }
 
 func (cmlas *cmlArgumentsSource) Config() interface{} {
@@ -38,46 +45,94 @@ const (
 	cmlapVALUE
 )
 
-var (
-	cmlArgumentsProviderInstance = &cmlArgumentsProvider{}
-	cmlLock                      = sync.Mutex{}
-	cmlLoaded                    = false
-)
-
 // CmlArgumentsProvider
-// Gets the singleton instance for the CML Arguments Provider
+// Gets the CML Arguments Provider of the default environment
 func CmlArgumentsProvider() *cmlArgumentsProvider {
-	return cmlArgumentsProviderInstance
+	return env.CmlArgumentsProvider()
 }
 
 func CmlArgumentsSource() *cmlArgumentsSource {
-	return &cmlArgumentsSource{}
+	return env.CmlArgumentsSource()
+}
+
+// CmlArgumentsProvider
+// Gets the CML Arguments Provider of the environment
+func (en *Environment) CmlArgumentsProvider() *cmlArgumentsProvider {
+	return en.cmlProvider
+}
+
+// CmlArgumentsSource
+// Creates a source for variables provided by the CML Arguments Provider of the environment
+func (en *Environment) CmlArgumentsSource() *cmlArgumentsSource {
+	return en.cmlProvider.Source()
+}
+
+// Source
+// Creates a source for variables provided by this provider instance
+func (cmlap *cmlArgumentsProvider) Source() *cmlArgumentsSource {
+	return &cmlArgumentsSource{
+		provider: cmlap,
+	}
 }
 
 // Get
 // Gets the value of the given property, if defined.
 func (cmlap *cmlArgumentsProvider) Get(name string, config interface{}) interface{} {
-	if !cmlLoaded {
-		cmlLock.Lock()
-		if !cmlLoaded {
-			_ = cmlap.Load()
-			cmlLoaded = true
+	switches := cmlap.loadedSwitches()
+	for _, switchName := range cmlap.variableNames(name, config) {
+		if v, found := switches[switchName]; found {
+			return v
 		}
-		cmlLock.Unlock()
 	}
+	return nil
+}
 
-	variableName := name
+// switchValue gets the value of a switch given by its exact name, if set
+func (cmlap *cmlArgumentsProvider) switchValue(name string) interface{} {
+	if v, found := cmlap.loadedSwitches()[name]; found {
+		return v
+	}
+	return nil
+}
+
+// UseNaming
+// Sets the strategy giving the switch names of variables (see KebabNaming). Variables are looked for by their own
+// name if no strategy is set.
+func (cmlap *cmlArgumentsProvider) UseNaming(strategy NamingStrategy) *cmlArgumentsProvider {
+	cmlap.lock.Lock()
+	cmlap.naming = strategy
+	cmlap.lock.Unlock()
+	return cmlap
+}
+
+// variableNames gets the switch names for a variable, which may be configured in its source
+func (cmlap *cmlArgumentsProvider) variableNames(name string, config interface{}) []string {
+	scoped := false
 	if source, isType := config.(*cmlArgumentsSource); isType {
 		if source.name != nil {
-			variableName = *source.name
+			return []string{*source.name}
 		}
+		scoped = source.scoped
+	}
+	cmlap.lock.Lock()
+	naming := cmlap.naming
+	cmlap.lock.Unlock()
+	if naming == nil && scoped {
+		naming = KebabNaming
 	}
+	return namesOf(naming, name)
+}
 
-	v, found := cmlap.switches[variableName]
-	if found {
-		return v
+// locate describes the switch where the variable is found, or the ones where it's looked for
+func (cmlap *cmlArgumentsProvider) locate(name string, config interface{}) string {
+	switches := cmlap.loadedSwitches()
+	names := cmlap.variableNames(name, config)
+	for _, switchName := range names {
+		if _, found := switches[switchName]; found {
+			return "switch -" + switchName
+		}
 	}
-	return nil
+	return "switch -" + strings.Join(names, " or -")
 }
 
 // Load
@@ -86,12 +141,12 @@ func (cmlap *cmlArgumentsProvider) Get(name string, config interface{}) interfac
 // single values when used with the assignment operator (=) or the whole value
 // set after a space until the next switch or end of arguments.
 func (cmlap *cmlArgumentsProvider) Load() error {
-	cmlap.args = os.Args
-	cmlap.switches = make(map[string]string)
+	args := os.Args
+	switches := make(map[string]string)
 
 	previousContext := cmlapSTART
 	var currentSwitch string
-	for _, arg := range cmlap.args[1:] {
+	for _, arg := range args[1:] {
 		if arg[0] == '-' {
 			// argument is a switch check if it's a long switch
 			if arg[1] == '-' {
@@ -109,22 +164,29 @@ func (cmlap *cmlArgumentsProvider) Load() error {
 			if i := strings.IndexByte(currentSwitch, '='); i > 0 {
 				currentValue := currentSwitch[i+1:]
 				currentSwitch = currentSwitch[:i]
-				cmlap.switches[currentSwitch] = currentValue
+				switches[currentSwitch] = currentValue
 				previousContext = cmlapVALUE
 			} else {
-				cmlap.switches[currentSwitch] = ""
+				switches[currentSwitch] = ""
 				previousContext = cmlapSWITCH
 			}
 		} else if previousContext == cmlapSWITCH {
-			if len(cmlap.switches[currentSwitch]) == 0 {
-				cmlap.switches[currentSwitch] = arg
+			if len(switches[currentSwitch]) == 0 {
+				switches[currentSwitch] = arg
 			} else {
-				cmlap.switches[currentSwitch] = cmlap.switches[currentSwitch] + " " + arg
+				switches[currentSwitch] = switches[currentSwitch] + " " + arg
 			}
 		}
 
 		// non contextualized values are currently not indexed
 	}
+
+	// the parsed switches are only published once complete, readers never see a partially filled map
+	cmlap.lock.Lock()
+	cmlap.args = args
+	cmlap.switches = switches
+	cmlap.loaded = true
+	cmlap.lock.Unlock()
 	return nil
 }
 
@@ -133,3 +195,41 @@ func (cmlap *cmlArgumentsProvider) Load() error {
 func (cmlap *cmlArgumentsProvider) Refresh() (bool, error) {
 	return false, nil
 }
+
+// Name
+// Identifies the provider as cml.
+func (cmlap *cmlArgumentsProvider) Name() string {
+	return "cml"
+}
+
+// Description
+// Describes the provider as the command line arguments.
+func (cmlap *cmlArgumentsProvider) Description() string {
+	return "command line arguments"
+}
+
+// loadedSwitches gets the switches set in the command line, parsing it if it was not loaded yet
+func (cmlap *cmlArgumentsProvider) loadedSwitches() map[string]string {
+	cmlap.loadOnce.Do(func() {
+		cmlap.lock.Lock()
+		loaded := cmlap.loaded
+		cmlap.lock.Unlock()
+		if !loaded {
+			_ = cmlap.Load()
+		}
+	})
+	cmlap.lock.Lock()
+	defer cmlap.lock.Unlock()
+	return cmlap.switches
+}
+
+// Keys
+// Gets the names of the switches set in the command line under the given prefix.
+func (cmlap *cmlArgumentsProvider) Keys(prefix string) []string {
+	switches := cmlap.loadedSwitches()
+	names := make([]string, 0, len(switches))
+	for name := range switches {
+		names = append(names, name)
+	}
+	return filterKeys(names, prefix)
+}
@@ -0,0 +1,479 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomatbase/go-error"
+)
+
+const (
+	ErrDotEnvSyntax = err.ErrorF("Invalid dotenv file %s at line %d: %s")
+)
+
+type dotEnvConfigurationProvider struct {
+	options   DotEnvConfigurationProviderOptions
+	filename  string
+	timestamp time.Time
+	lock      sync.Mutex
+	values    map[string]string
+	// cml is the provider of the environment the provider belongs to, the default environment one if nil
+	cml *cmlArgumentsProvider
+}
+
+type dotEnvConfigurationSource struct {
+	provider *dotEnvConfigurationProvider
+	name     *string
+}
+
+func (decs *dotEnvConfigurationSource) Provider() Provider {
+	return decs.provider
+}
+
+func (decs *dotEnvConfigurationSource) Config() interface{} {
+	return decs
+}
+
+func (decs *dotEnvConfigurationSource) Name(name string) *dotEnvConfigurationSource {
+	decs.name = &name
+	return decs
+}
+
+type DotEnvConfigurationProviderOptions struct {
+	// FileFromCml gets the filename from the CmlSwitch switch, when given, instead of Filename
+	FileFromCml bool
+	CmlSwitch   string
+	Filename    string
+	// Naming is the strategy giving the names of variables in the file (see EnvironmentNaming)
+	Naming NamingStrategy
+}
+
+var defaultDotEnvConfigurationProviderOptions = DotEnvConfigurationProviderOptions{
+	FileFromCml: true,
+	CmlSwitch:   "e",
+}
+
+// DotEnvConfigurationProvider
+// Gets the dotenv configuration Provider of the default environment. The provider is not one of the default sources,
+// it must be added to the environment sources (like with AddBefore(EnvironmentVariablesProvider(),
+// DotEnvConfigurationSource())).
+func DotEnvConfigurationProvider() *dotEnvConfigurationProvider {
+	return env.DotEnvConfigurationProvider()
+}
+
+// DotEnvConfigurationProviderWithOptions
+// Gets the dotenv configuration Provider of the default environment. Options are ignored as the provider is created
+// with the environment, environments with other options are created with New (see Settings)
+func DotEnvConfigurationProviderWithOptions(options DotEnvConfigurationProviderOptions) *dotEnvConfigurationProvider {
+	return env.DotEnvConfigurationProvider()
+}
+
+// DotEnvConfigurationProvider
+// Gets the dotenv configuration Provider of the environment. It's not one of the default sources of the environment.
+func (en *Environment) DotEnvConfigurationProvider() *dotEnvConfigurationProvider {
+	return en.dotEnvProvider
+}
+
+// DotEnvConfigurationSource
+// Creates a source for variables provided by the dotenv configuration Provider of the environment
+func (en *Environment) DotEnvConfigurationSource() *dotEnvConfigurationSource {
+	return en.dotEnvProvider.Source()
+}
+
+// NewDotEnvConfigurationProvider
+// Creates a new dotenv configuration Provider
+func NewDotEnvConfigurationProvider() *dotEnvConfigurationProvider {
+	return NewDotEnvConfigurationProviderWithOptions(defaultDotEnvConfigurationProviderOptions)
+}
+
+// NewDotEnvConfigurationProviderWithOptions
+// Creates a new dotenv configuration Provider with given options
+func NewDotEnvConfigurationProviderWithOptions(options DotEnvConfigurationProviderOptions) *dotEnvConfigurationProvider {
+	return newDotEnvConfigurationProvider(options, nil)
+}
+
+// newDotEnvConfigurationProvider creates a dotenv configuration Provider using the given cml provider
+func newDotEnvConfigurationProvider(options DotEnvConfigurationProviderOptions, cml *cmlArgumentsProvider) *dotEnvConfigurationProvider {
+	decp := &dotEnvConfigurationProvider{
+		options: options,
+		cml:     cml,
+	}
+	_ = decp.Load()
+	return decp
+}
+
+func DotEnvConfigurationSource() *dotEnvConfigurationSource {
+	return env.DotEnvConfigurationSource()
+}
+
+// Source
+// Creates a source for variables provided by this provider instance
+func (decp *dotEnvConfigurationProvider) Source() *dotEnvConfigurationSource {
+	return &dotEnvConfigurationSource{
+		provider: decp,
+	}
+}
+
+// Load
+// Loads the dotenv file, which is the one given in the command line, if allowed, or the configured one. This is the
+// only time when the filename is resolved as the source is not expected to change for a refresh.
+func (decp *dotEnvConfigurationProvider) Load() error {
+	filename := decp.options.Filename
+	if decp.options.FileFromCml {
+		if v := decp.arguments().Get(decp.options.CmlSwitch, nil); v != nil {
+			filename = v.(string)
+		}
+	}
+	decp.lock.Lock()
+	decp.filename = filename
+	decp.timestamp = time.Time{} // forces the file to be read again
+	decp.values = nil
+	decp.lock.Unlock()
+	_, e := decp.Refresh()
+	return e
+}
+
+// Refresh
+// Reloads the dotenv file if it changed. If no file is configured, it is a nil operation.
+func (decp *dotEnvConfigurationProvider) Refresh() (bool, error) {
+	decp.lock.Lock()
+	defer decp.lock.Unlock()
+	if decp.filename == "" {
+		return false, nil
+	}
+	stat, e := os.Stat(decp.filename)
+	if e != nil {
+		return false, e
+	}
+	if !stat.ModTime().After(decp.timestamp) {
+		return false, nil
+	}
+	b, e := ioutil.ReadFile(decp.filename)
+	if e != nil {
+		log.Printf("%s: unable to read file : \"%v\"", decp.Name(), e)
+		return false, e
+	}
+	values, e := parseDotEnv(decp.filename, string(b))
+	if e != nil {
+		return false, e
+	}
+	decp.values = values
+	decp.timestamp = stat.ModTime()
+	return true, nil
+}
+
+// Get
+// Gets the value of the given variable from the dotenv file, if available.
+func (decp *dotEnvConfigurationProvider) Get(name string, config interface{}) interface{} {
+	if value, found := decp.find(decp.variableNames(name, config)); found != "" {
+		return value
+	}
+	return nil
+}
+
+// UseNaming
+// Sets the strategy giving the names of variables in the file (see EnvironmentNaming). Variables are looked for by
+// their own name if no strategy is set.
+func (decp *dotEnvConfigurationProvider) UseNaming(strategy NamingStrategy) *dotEnvConfigurationProvider {
+	decp.lock.Lock()
+	decp.options.Naming = strategy
+	decp.lock.Unlock()
+	return decp
+}
+
+// variableNames gets the names in the file for a variable, which may be configured in its source
+func (decp *dotEnvConfigurationProvider) variableNames(name string, config interface{}) []string {
+	if source, isType := config.(*dotEnvConfigurationSource); isType {
+		if source.name != nil {
+			return []string{*source.name}
+		}
+	}
+	decp.lock.Lock()
+	naming := decp.options.Naming
+	decp.lock.Unlock()
+	return namesOf(naming, name)
+}
+
+// find gets the value of the first of the given names set in the file, with the name it was found with
+func (decp *dotEnvConfigurationProvider) find(names []string) (string, string) {
+	decp.lock.Lock()
+	defer decp.lock.Unlock()
+	for _, name := range names {
+		if value, found := decp.values[name]; found {
+			return value, name
+		}
+	}
+	return "", ""
+}
+
+// locate describes the variable of the file where the variable is found, or the ones where it's looked for
+func (decp *dotEnvConfigurationProvider) locate(name string, config interface{}) string {
+	names := decp.variableNames(name, config)
+	_, variableName := decp.find(names)
+	if variableName == "" {
+		variableName = strings.Join(names, " or ")
+	}
+	decp.lock.Lock()
+	defer decp.lock.Unlock()
+	if decp.filename == "" {
+		return "variable " + variableName + " (no dotenv file)"
+	}
+	return "variable " + variableName + " in " + decp.filename
+}
+
+// DependsOn
+// The dotenv provider gets its filename from the command line.
+func (decp *dotEnvConfigurationProvider) DependsOn() []Provider {
+	return []Provider{decp.arguments()}
+}
+
+// arguments gets the cml provider the filename is taken from
+func (decp *dotEnvConfigurationProvider) arguments() *cmlArgumentsProvider {
+	if decp.cml != nil {
+		return decp.cml
+	}
+	return CmlArgumentsProvider()
+}
+
+// Name
+// Identifies the provider as dotenv.
+func (decp *dotEnvConfigurationProvider) Name() string {
+	return "dotenv"
+}
+
+// Description
+// Describes the dotenv file read by the provider.
+func (decp *dotEnvConfigurationProvider) Description() string {
+	decp.lock.Lock()
+	defer decp.lock.Unlock()
+	if decp.filename == "" {
+		return "no file"
+	}
+	return "file " + decp.filename
+}
+
+// Keys
+// Gets the names of the variables of the dotenv file under the given prefix.
+func (decp *dotEnvConfigurationProvider) Keys(prefix string) []string {
+	decp.lock.Lock()
+	names := make([]string, 0, len(decp.values))
+	for name := range decp.values {
+		names = append(names, name)
+	}
+	decp.lock.Unlock()
+	return filterKeys(names, prefix)
+}
+
+// dotEnvParser reads the assignments of a dotenv file
+type dotEnvParser struct {
+	filename string
+	content  string
+	position int
+	line     int
+	values   map[string]string
+}
+
+// parseDotEnv parses the content of a dotenv file, made of NAME=value assignments, one per line and optionally
+// preceded by export, blank lines and # comments. Values may be:
+//
+//	unquoted      trimmed, up to a # comment preceded by a space
+//	'single'      taken literally, may span several lines
+//	"double"      with \n, \r, \t, \", \\ and \$ escapes, may span several lines
+//
+// ${NAME} and ${NAME:-default} in unquoted and double-quoted values are expanded with the variables previously set in
+// the file or, if not set, with the environment variables. Unset variables with no default expand to nothing.
+func parseDotEnv(filename string, content string) (map[string]string, error) {
+	parser := &dotEnvParser{
+		filename: filename,
+		content:  strings.ReplaceAll(content, "\r\n", "\n"),
+		line:     1,
+		values:   make(map[string]string),
+	}
+	for parser.position < len(parser.content) {
+		if e := parser.assignment(); e != nil {
+			return nil, e
+		}
+	}
+	return parser.values, nil
+}
+
+func (p *dotEnvParser) fail(format string, values ...interface{}) error {
+	return ErrDotEnvSyntax.WithValues(p.filename, p.line, fmt.Sprintf(format, values...))
+}
+
+// nextLine moves past the end of the current line
+func (p *dotEnvParser) nextLine() {
+	if i := strings.IndexByte(p.content[p.position:], '\n'); i >= 0 {
+		p.position += i + 1
+	} else {
+		p.position = len(p.content)
+	}
+	p.line++
+}
+
+// restOfLine gets the remaining of the current line, without moving
+func (p *dotEnvParser) restOfLine() string {
+	rest := p.content[p.position:]
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		return rest[:i]
+	}
+	return rest
+}
+
+// assignment reads the assignment of the current line, if any, moving to the next line
+func (p *dotEnvParser) assignment() error {
+	line := strings.TrimSpace(p.restOfLine())
+	if line == "" || line[0] == '#' {
+		p.nextLine()
+		return nil
+	}
+
+	p.position += strings.Index(p.content[p.position:], line)
+	if strings.HasPrefix(line, "export ") || strings.HasPrefix(line, "export\t") {
+		p.position += len("export")
+		line = strings.TrimLeft(line[len("export"):], " \t")
+		p.position += strings.Index(p.content[p.position:], line)
+	}
+	separator := strings.IndexByte(line, '=')
+	if separator < 0 {
+		return p.fail("missing = in %s", line)
+	}
+	name := strings.TrimSpace(line[:separator])
+	if !validDotEnvName(name) {
+		return p.fail("invalid name %s", name)
+	}
+	p.position += separator + 1
+	for p.position < len(p.content) && (p.content[p.position] == ' ' || p.content[p.position] == '\t') {
+		p.position++
+	}
+
+	var value string
+	var e error
+	if p.position < len(p.content) && (p.content[p.position] == '\'' || p.content[p.position] == '"') {
+		value, e = p.quoted()
+	} else {
+		value = p.restOfLine()
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		} else if i = strings.Index(value, "\t#"); i >= 0 {
+			value = value[:i]
+		}
+		value = p.expand(strings.TrimSpace(value), false)
+		p.nextLine()
+	}
+	if e != nil {
+		return e
+	}
+	p.values[name] = value
+	return nil
+}
+
+// quoted reads a quoted value, moving past the end of the line where it's closed
+func (p *dotEnvParser) quoted() (string, error) {
+	quote := p.content[p.position]
+	start := p.line
+	buffer := &bytes.Buffer{}
+	i := p.position + 1
+	for ; i < len(p.content) && p.content[i] != quote; i++ {
+		c := p.content[i]
+		switch {
+		case c == '\n':
+			p.line++
+		case c == '\\' && quote == '"' && i+1 < len(p.content):
+			// backslashes are kept escaped, with escaped dollar signs, until the value is expanded
+			i++
+			switch p.content[i] {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case '"':
+				c = '"'
+			case '$':
+				buffer.WriteByte('\\')
+				c = '$'
+			case '\\':
+				buffer.WriteByte('\\')
+			default:
+				buffer.WriteString("\\\\")
+				c = p.content[i]
+			}
+		case c == '\\' && quote == '"':
+			buffer.WriteByte('\\')
+		}
+		buffer.WriteByte(c)
+	}
+	if i == len(p.content) {
+		p.line = start
+		return "", p.fail("unterminated quoted value")
+	}
+
+	p.position = i + 1
+	if rest := strings.TrimSpace(p.restOfLine()); rest != "" && rest[0] != '#' {
+		return "", p.fail("unexpected %s after quoted value", rest)
+	}
+	p.nextLine()
+	if quote == '\'' {
+		return buffer.String(), nil
+	}
+	return p.expand(buffer.String(), true), nil
+}
+
+// expand replaces the ${NAME} and ${NAME:-default} placeholders of a value. Dollar signs escaped with a backslash are
+// taken literally, as well as escaped backslashes if the value is escaped.
+func (p *dotEnvParser) expand(text string, escaped bool) string {
+	buffer := &bytes.Buffer{}
+	for i := 0; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], "\\$") || escaped && strings.HasPrefix(text[i:], "\\\\"):
+			buffer.WriteByte(text[i+1])
+			i++
+		case strings.HasPrefix(text[i:], "${"):
+			end := closingBrace(text, i+2)
+			if end < 0 {
+				buffer.WriteString(text[i:])
+				return buffer.String()
+			}
+			name, defaultValue := text[i+2:end], ""
+			if separator := strings.Index(name, ":-"); separator >= 0 {
+				name, defaultValue = name[:separator], name[separator+2:]
+			}
+			if value, found := p.values[name]; found {
+				buffer.WriteString(value)
+			} else if value, found = os.LookupEnv(name); found {
+				buffer.WriteString(value)
+			} else {
+				buffer.WriteString(p.expand(defaultValue, escaped))
+			}
+			i = end
+		default:
+			buffer.WriteByte(text[i])
+		}
+	}
+	return buffer.String()
+}
+
+// validDotEnvName checks if a name is made of letters, digits, underscores, dots and dashes, not starting with a digit
+func validDotEnvName(name string) bool {
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -6,16 +6,31 @@ package env
 
 import (
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 )
 
-type environmentVariablesProvider struct{}
+type environmentVariablesProvider struct {
+	naming     NamingStrategy
+	prefix     string
+	snapshot   map[string]string
+	changes    []string
+	frozen     bool
+	structured bool
+	lock       sync.Mutex
+}
 
 type environmentVariablesSource struct {
-	name *string
+	provider *environmentVariablesProvider
+	name     *string
+	// scoped sources look for variables under the prefix of a scope also by their environment form (see Scope)
+	scoped bool
 }
 
 func (evs *environmentVariablesSource) Provider() Provider {
-	return environmentVariablesProviderInstance
+	return evs.provider
 }
 
 func (evs *environmentVariablesSource) Config() interface{} {
@@ -27,40 +42,314 @@ func (evs *environmentVariablesSource) Name(name string) *environmentVariablesSo
 	return evs
 }
 
-var environmentVariablesProviderInstance = &environmentVariablesProvider{}
-
 // EnvironmentVariablesProvider
-// Gets the Environment Variables Provider instance (Singleton)
+// Gets the Environment Variables Provider of the default environment
 func EnvironmentVariablesProvider() *environmentVariablesProvider {
-	return environmentVariablesProviderInstance
+	return env.EnvironmentVariablesProvider()
 }
 
 func EnvironmentVariablesSource() *environmentVariablesSource {
-	return &environmentVariablesSource{}
+	return env.EnvironmentVariablesSource()
+}
+
+// EnvironmentVariablesProvider
+// Gets the Environment Variables Provider of the environment
+func (en *Environment) EnvironmentVariablesProvider() *environmentVariablesProvider {
+	return en.envProvider
+}
+
+// EnvironmentVariablesSource
+// Creates a source for variables provided by the Environment Variables Provider of the environment
+func (en *Environment) EnvironmentVariablesSource() *environmentVariablesSource {
+	return en.envProvider.Source()
+}
+
+// Source
+// Creates a source for variables provided by this provider instance
+func (evp *environmentVariablesProvider) Source() *environmentVariablesSource {
+	return &environmentVariablesSource{
+		provider: evp,
+	}
 }
 
 // Load
-// Loads the environment variables. This is a nil operation as the environment variables are always taken directly from os calls
+// Takes a snapshot of the environment variables, which refreshes are compared with. Variables are still read directly
+// from os calls unless reads are frozen to the snapshot (see UseSnapshot).
 func (evp *environmentVariablesProvider) Load() error {
+	evp.lock.Lock()
+	evp.snapshot = environment()
+	evp.changes = nil
+	evp.lock.Unlock()
 	return nil
 }
 
 // Refresh
-// Refreshes the environment variables. This is a nil operation as the environment variables are always taken directly from os calls
+// Compares the environment variables with the snapshot taken when loaded or last refreshed, reporting an update if
+// any of them was set, changed or unset (see ChangedKeys). The snapshot is replaced by the current environment.
 func (evp *environmentVariablesProvider) Refresh() (bool, error) {
-	return false, nil
+	current := environment()
+	evp.lock.Lock()
+	defer evp.lock.Unlock()
+	if evp.snapshot == nil {
+		evp.snapshot = current
+		return false, nil
+	}
+	var changes []string
+	for name, value := range current {
+		if previous, found := evp.snapshot[name]; !found || previous != value {
+			changes = append(changes, name)
+		}
+	}
+	for name := range evp.snapshot {
+		if _, found := current[name]; !found {
+			changes = append(changes, name)
+		}
+	}
+	sort.Strings(changes)
+	evp.changes = changes
+	evp.snapshot = current
+	return len(changes) > 0, nil
+}
+
+// ChangedKeys
+// Gets the names of the environment variables set, changed or unset found by the last refresh, sorted.
+func (evp *environmentVariablesProvider) ChangedKeys() []string {
+	evp.lock.Lock()
+	defer evp.lock.Unlock()
+	return append([]string{}, evp.changes...)
+}
+
+// UseSnapshot
+// Freezes reads to the snapshot of the environment variables taken when loaded or last refreshed, so that variables
+// only change when the provider is refreshed, consistently with the other providers. Variables are read directly from
+// os calls otherwise, which is the default.
+func (evp *environmentVariablesProvider) UseSnapshot(frozen bool) *environmentVariablesProvider {
+	evp.lock.Lock()
+	evp.frozen = frozen
+	evp.lock.Unlock()
+	return evp
+}
+
+// lookup gets the value of an environment variable, from the snapshot if reads are frozen
+func (evp *environmentVariablesProvider) lookup(name string) (string, bool) {
+	evp.lock.Lock()
+	if evp.frozen && evp.snapshot != nil {
+		value, found := evp.snapshot[name]
+		evp.lock.Unlock()
+		return value, found
+	}
+	evp.lock.Unlock()
+	return os.LookupEnv(name)
+}
+
+// variables gets the environment variables as name=value, from the snapshot if reads are frozen
+func (evp *environmentVariablesProvider) variables() []string {
+	evp.lock.Lock()
+	defer evp.lock.Unlock()
+	if !evp.frozen || evp.snapshot == nil {
+		return os.Environ()
+	}
+	variables := make([]string, 0, len(evp.snapshot))
+	for name, value := range evp.snapshot {
+		variables = append(variables, name+"="+value)
+	}
+	return variables
+}
+
+// environment gets the current environment variables by name
+func environment() map[string]string {
+	variables := os.Environ()
+	result := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		if i := strings.IndexByte(variable, '='); i > 0 {
+			result[variable[:i]] = variable[i+1:]
+		}
+	}
+	return result
 }
 
+// Get
+// Gets the value of the first environment variable named after the variable (see UseNaming). If there's none and
+// structured values are enabled (see UseStructuredValues), the value is rebuilt from the environment variables under
+// the variable name, if any.
 func (evp *environmentVariablesProvider) Get(name string, config interface{}) interface{} {
-	variableName := name
+	for _, variableName := range evp.variableNames(name, config) {
+		if v, found := evp.lookup(variableName); found {
+			return v
+		}
+	}
+	return evp.structuredValue(name, config)
+}
+
+// UseStructuredValues
+// Enables rebuilding objects and lists from the environment variables under a variable name when there's no
+// environment variable for the variable itself (APP_SERVERS_0_HOST and APP_LABELS_TEAM give servers and labels, see
+// structured). It's disabled by default, as unrelated environment variables sharing a name prefix (like JAVA_HOME for
+// java) would otherwise provide values for variables, overriding their defaults.
+func (evp *environmentVariablesProvider) UseStructuredValues(enabled bool) *environmentVariablesProvider {
+	evp.lock.Lock()
+	evp.structured = enabled
+	evp.lock.Unlock()
+	return evp
+}
+
+// structuredValue rebuilds the value of a variable from the environment variables under its name, nil if there are
+// none or structured values are not enabled
+func (evp *environmentVariablesProvider) structuredValue(name string, config interface{}) interface{} {
+	evp.lock.Lock()
+	enabled := evp.structured
+	evp.lock.Unlock()
+	if !enabled {
+		return nil
+	}
+	return structured(evp.variables(), evp.structureName(name, config))
+}
+
+// UseNaming
+// Sets the strategy giving the environment variable names of variables (see EnvironmentNaming). Variables are looked
+// for by their own name if no strategy is set.
+func (evp *environmentVariablesProvider) UseNaming(strategy NamingStrategy) *environmentVariablesProvider {
+	evp.lock.Lock()
+	evp.naming = strategy
+	evp.lock.Unlock()
+	return evp
+}
+
+// UsePrefix
+// Sets the application prefix of the environment variable names of variables (like MYAPP_), which is prepended to the
+// names given by the naming strategy.
+func (evp *environmentVariablesProvider) UsePrefix(prefix string) *environmentVariablesProvider {
+	evp.lock.Lock()
+	evp.prefix = prefix
+	evp.lock.Unlock()
+	return evp
+}
+
+// variableNames gets the environment variable names for a variable, which may be configured in its source
+func (evp *environmentVariablesProvider) variableNames(name string, config interface{}) []string {
+	scoped := false
 	if source, isType := config.(*environmentVariablesSource); isType {
 		if source.name != nil {
-			variableName = *source.name
+			return []string{*source.name}
 		}
+		scoped = source.scoped
+	}
+	evp.lock.Lock()
+	naming, prefix := evp.naming, evp.prefix
+	evp.lock.Unlock()
+	if naming == nil && scoped {
+		naming = EnvironmentNaming
+	}
+	names := namesOf(naming, name)
+	for i := range names {
+		names[i] = prefix + names[i]
 	}
+	return names
+}
 
-	if v, found := os.LookupEnv(variableName); found {
-		return v
+// structureName gets the name of the environment variables holding the structured value of a variable, which is its
+// explicit name in the source or its name in upper case preceded by the application prefix (APP_SERVERS for servers)
+func (evp *environmentVariablesProvider) structureName(name string, config interface{}) string {
+	if source, isType := config.(*environmentVariablesSource); isType {
+		if source.name != nil {
+			return *source.name
+		}
 	}
-	return nil
+	evp.lock.Lock()
+	defer evp.lock.Unlock()
+	return evp.prefix + environmentName(name)
+}
+
+// locate describes the environment variable where the variable is found, or the ones where it's looked for
+func (evp *environmentVariablesProvider) locate(name string, config interface{}) string {
+	names := evp.variableNames(name, config)
+	for _, variableName := range names {
+		if _, found := evp.lookup(variableName); found {
+			return "environment variable " + variableName
+		}
+	}
+	if evp.structuredValue(name, config) != nil {
+		return "environment variables " + evp.structureName(name, config) + "_*"
+	}
+	return "environment variable " + strings.Join(names, " or ")
+}
+
+// structured rebuilds a structured value from the environment variables (as name=value) under the given name, where underscores
+// separate the lower-cased keys of objects (APP_LABELS_TEAM gives the team of the labels) and the indices of lists
+// (APP_SERVERS_0_HOST gives the host of the first server). Objects whose keys are all the indices from 0 are lists.
+// Returns nil if there are no such variables.
+func structured(environment []string, name string) interface{} {
+	prefix := name + "_"
+	var variables []string
+	for _, variable := range environment {
+		if strings.HasPrefix(variable, prefix) {
+			variables = append(variables, variable)
+		}
+	}
+	if len(variables) == 0 {
+		return nil
+	}
+	// sorted so that nested values consistently replace the values of their parent names
+	sort.Strings(variables)
+
+	object := make(map[string]interface{})
+	for _, variable := range variables {
+		i := strings.IndexByte(variable, '=')
+		if i < len(prefix) {
+			continue
+		}
+		keys := strings.Split(strings.ToLower(variable[len(prefix):i]), "_")
+		if contains(keys, "") {
+			continue
+		}
+		setPath(object, keys, variable[i+1:])
+	}
+	if len(object) == 0 {
+		return nil
+	}
+	return asLists(object)
+}
+
+// asLists turns the objects of a structured value whose keys are all the indices from 0 into lists
+func asLists(value interface{}) interface{} {
+	object, isObject := value.(map[string]interface{})
+	if !isObject {
+		return value
+	}
+	for key, child := range object {
+		object[key] = asLists(child)
+	}
+	list := make([]interface{}, len(object))
+	for key, child := range object {
+		index, e := strconv.Atoi(key)
+		if e != nil || index < 0 || index >= len(list) || strconv.Itoa(index) != key {
+			return object
+		}
+		list[index] = child
+	}
+	return list
+}
+
+// Name
+// Identifies the provider as env.
+func (evp *environmentVariablesProvider) Name() string {
+	return "env"
+}
+
+// Description
+// Describes the provider as the environment variables.
+func (evp *environmentVariablesProvider) Description() string {
+	return "environment variables"
+}
+
+// Keys
+// Gets the names of the environment variables under the given prefix.
+func (evp *environmentVariablesProvider) Keys(prefix string) []string {
+	var names []string
+	for _, variable := range evp.variables() {
+		if i := strings.IndexByte(variable, '='); i > 0 {
+			names = append(names, variable[:i])
+		}
+	}
+	return filterKeys(names, prefix)
 }
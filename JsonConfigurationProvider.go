@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"log"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -17,8 +16,14 @@ import (
 type jsonConfigurationProvider struct {
 	options   JsonConfigurationProviderOptions
 	timestamp time.Time
+	files     []string
 	lock      sync.Mutex
-	json      *map[string]interface{}
+	// cml, environment and selector are the providers and profile selection of the environment the provider belongs
+	// to, the default environment ones if nil
+	cml         *cmlArgumentsProvider
+	environment *environmentVariablesProvider
+	selector    *profileSelector
+	json        *map[string]interface{}
 }
 
 type jsonConfigurationSource struct {
@@ -45,6 +50,14 @@ type JsonConfigurationProviderOptions struct {
 	CmlPropertyOverride       bool
 	CmlPropertyOverrideSwitch string
 	Filename                  string
+	// IgnoreProfiles disables the overlay of the configuration files of the active profiles
+	IgnoreProfiles bool
+	// Naming is the strategy giving the property names of variables (see RelaxedNaming)
+	Naming NamingStrategy
+	// EnvPropertyOverride allows properties to be overridden by environment variables named after them, with dots and
+	// list indices as underscores and preceded by EnvPropertyOverridePrefix (APP_SERVERS_0_HOST for servers[0].host)
+	EnvPropertyOverride       bool
+	EnvPropertyOverridePrefix string
 }
 
 var defaultJsonConfigurationProviderOptions = JsonConfigurationProviderOptions{
@@ -52,48 +65,62 @@ var defaultJsonConfigurationProviderOptions = JsonConfigurationProviderOptions{
 	CmlSwitch:                 "j",
 	CmlPropertyOverride:       true,
 	CmlPropertyOverrideSwitch: "J",
+	EnvPropertyOverridePrefix: "APP_",
 }
 
-var jsonConfigurationProviderDefaultInstance *jsonConfigurationProvider
-var jcpMutex = sync.Mutex{}
-
 // JsonConfigurationProvider
-// Gets or creates the default JSON configuration Provider instance (Singleton) with default Options
+// Gets the JSON configuration Provider of the default environment
 func JsonConfigurationProvider() *jsonConfigurationProvider {
-	if jsonConfigurationProviderDefaultInstance == nil {
-		return JsonConfigurationProviderWithOptions(defaultJsonConfigurationProviderOptions)
-	}
-	return jsonConfigurationProviderDefaultInstance
-
+	return env.JsonConfigurationProvider()
 }
 
 // JsonConfigurationProviderWithOptions
-// Gets or creates the default JSON Configuration Provider instance (Singleton) with given Options. Options are
-// ignored if there is already a default instance initialized
+// Gets the JSON configuration Provider of the default environment. Options are ignored as the provider is created with
+// the environment, environments with other options are created with New (see Settings)
 func JsonConfigurationProviderWithOptions(options JsonConfigurationProviderOptions) *jsonConfigurationProvider {
-	if jsonConfigurationProviderDefaultInstance == nil {
-		jcpMutex.Lock() // lock only for the moment where the default instance might be updated
-		if jsonConfigurationProviderDefaultInstance == nil {
-			jsonConfigurationProviderDefaultInstance = NewJsonConfigurationProviderWithOptions(options)
-		}
-		jcpMutex.Unlock()
-	}
-	return jsonConfigurationProviderDefaultInstance
+	return env.JsonConfigurationProvider()
+}
+
+// JsonConfigurationProvider
+// Gets the JSON configuration Provider of the environment
+func (en *Environment) JsonConfigurationProvider() *jsonConfigurationProvider {
+	return en.jsonProvider
+}
+
+// JsonConfigurationSource
+// Creates a source for variables provided by the JSON configuration Provider of the environment
+func (en *Environment) JsonConfigurationSource() *jsonConfigurationSource {
+	return en.jsonProvider.Source()
 }
 
 // NewJsonConfigurationProviderWithOptions
 // Creates a new JSON configuration Provider with given options
 func NewJsonConfigurationProviderWithOptions(options JsonConfigurationProviderOptions) *jsonConfigurationProvider {
+	return newJsonConfigurationProvider(options, nil, nil, nil)
+}
+
+// newJsonConfigurationProvider creates a JSON configuration Provider using the given cml and environment variables
+// providers and profile selection
+func newJsonConfigurationProvider(options JsonConfigurationProviderOptions, cml *cmlArgumentsProvider, environment *environmentVariablesProvider, profiles *profileSelector) *jsonConfigurationProvider {
 	jcp := &jsonConfigurationProvider{
-		options: options,
+		options:     options,
+		cml:         cml,
+		environment: environment,
+		selector:    profiles,
 	}
 	_ = jcp.Load()
 	return jcp
 }
 
 func JsonConfigurationSource() *jsonConfigurationSource {
+	return env.JsonConfigurationSource()
+}
+
+// Source
+// Creates a source for variables provided by this provider instance
+func (jcp *jsonConfigurationProvider) Source() *jsonConfigurationSource {
 	return &jsonConfigurationSource{
-		provider: JsonConfigurationProvider(),
+		provider: jcp,
 	}
 }
 
@@ -101,41 +128,61 @@ func JsonConfigurationSource() *jsonConfigurationSource {
 // Loads the json configuration file. This is the only time when the filename is
 // resolved as the source is not expected to change for a refresh.
 func (jcp *jsonConfigurationProvider) Load() error {
-	if jcp.options.FileFromCml {
-		if v := CmlArgumentsProvider().Get(jcp.options.CmlSwitch, nil); v != nil {
-			jcp.options.Filename = v.(string)
-		} else {
-			jcp.options.Filename = ""
+	jcp.lock.Lock()
+	fileFromCml, cmlSwitch := jcp.options.FileFromCml, jcp.options.CmlSwitch
+	jcp.lock.Unlock()
+	filename := ""
+	if fileFromCml {
+		if v := jcp.arguments().Get(cmlSwitch, nil); v != nil {
+			filename = v.(string)
 		}
 	}
+	jcp.lock.Lock()
+	if fileFromCml {
+		jcp.options.Filename = filename
+	}
+	jcp.timestamp = time.Time{} // forces the files to be read again
+	jcp.files = nil
+	jcp.lock.Unlock()
 	_, e := jcp.Refresh()
 	return e
 }
 
 // Refresh
-// Reloads the configuration file. If no json file is configured, it is a nil operation.
+// Reloads the configuration file, overlaid with the files of the active profiles (see ActiveProfiles), if any of them
+// changed. Files are only read again when the latest modification time of the files is later than the one of the
+// files last read, or when the files to read change (like when profiles are activated), and the refresh reports no
+// update otherwise. Files rewritten with the same modification time (as by tools preserving times or in file systems
+// with a coarse time resolution) are not reloaded. If no json file is configured, it is a nil operation.
 func (jcp *jsonConfigurationProvider) Refresh() (bool, error) {
-	if jcp.options.Filename != "" {
-		stat, e := os.Stat(jcp.options.Filename)
+	if _, filename := jcp.state(); filename != "" {
+		files, timestamp, e := configurationFiles(filename, jcp.profiles())
 		if e != nil {
 			return false, e
 		}
 		jcp.lock.Lock()
 		defer jcp.lock.Unlock()
-		if !stat.ModTime().After(jcp.timestamp) {
+		if !timestamp.After(jcp.timestamp) && equal(files, jcp.files) {
 			return false, nil
 		}
-		if b, e := ioutil.ReadFile(jcp.options.Filename); e != nil {
-			log.Printf("Unable to read json file : \"%v\"", e)
-			return false, e
-		} else {
-			jsonObject := make(map[string]interface{})
-			if e = json.Unmarshal(b, &jsonObject); e != nil {
+		var jsonObject interface{}
+		for _, file := range files {
+			if b, e := ioutil.ReadFile(file); e != nil {
+				log.Printf("%s: unable to read file : \"%v\"", jcp.Name(), e)
 				return false, e
+			} else {
+				fileObject := make(map[string]interface{})
+				if e = json.Unmarshal(b, &fileObject); e != nil {
+					return false, e
+				}
+				jsonObject = mergeValues(jsonObject, fileObject, MergeOptions{})
 			}
-			jcp.json = &jsonObject
-			return true, nil
 		}
+		merged := jsonObject.(map[string]interface{})
+		jcp.json = &merged
+		jcp.files = files
+		jcp.timestamp = timestamp
+		return true, nil
 	}
 	return false, nil
 }
@@ -144,42 +191,230 @@ func (jcp *jsonConfigurationProvider) Refresh() (bool, error) {
 // Gets the given property from the json file, if available.
 func (jcp *jsonConfigurationProvider) Get(name string, config interface{}) interface{} {
 	// If no json has been loaded, let's just return nil
-	if jcp.json == nil {
+	if loaded, _ := jcp.state(); loaded == nil {
 		return nil
 	}
 
-	variableName := name
+	value, _, _ := jcp.find(jcp.variableNames(name, config))
+	return value
+}
+
+// Lookup
+// Gets the value at the given path of the json configuration (see Lookup), failing if the path is not valid or walks
+// into a scalar value. Cml overrides are not considered.
+func (jcp *jsonConfigurationProvider) Lookup(path string) (interface{}, error) {
+	jcp.lock.Lock()
+	defer jcp.lock.Unlock()
+	if jcp.json == nil {
+		return nil, nil
+	}
+	return Lookup(*jcp.json, path)
+}
+
+// UseNaming
+// Sets the strategy giving the property names of variables (see RelaxedNaming). Variables are looked for by their own
+// name if no strategy is set.
+func (jcp *jsonConfigurationProvider) UseNaming(strategy NamingStrategy) *jsonConfigurationProvider {
+	jcp.lock.Lock()
+	jcp.options.Naming = strategy
+	jcp.lock.Unlock()
+	return jcp
+}
+
+// variableNames gets the property names for a variable, which may be configured in its source
+func (jcp *jsonConfigurationProvider) variableNames(name string, config interface{}) []string {
 	// let's check if a configuration is passed and if it's the right type
 	if config != nil {
 		if source, isType := config.(*jsonConfigurationSource); isType {
 			if source.name != nil {
-				variableName = *source.name
+				return []string{*source.name}
 			}
 		}
 	}
+	jcp.lock.Lock()
+	naming := jcp.options.Naming
+	jcp.lock.Unlock()
+	return namesOf(naming, name)
+}
+
+// UseEnvironmentOverrides
+// Allows properties to be overridden by environment variables named after them with the given prefix (see
+// EnvPropertyOverride).
+func (jcp *jsonConfigurationProvider) UseEnvironmentOverrides(prefix string) *jsonConfigurationProvider {
+	jcp.lock.Lock()
+	jcp.options.EnvPropertyOverride = true
+	jcp.options.EnvPropertyOverridePrefix = prefix
+	jcp.lock.Unlock()
+	return jcp
+}
 
+// find gets the value of the first of the given property names provided, with the name it was found with and where
+// it was overridden, if it was. Properties overridden in the command line are found first, then the ones overridden by
+// environment variables, if overrides are allowed. Objects and lists are found with their overridden values replaced.
+func (jcp *jsonConfigurationProvider) find(names []string) (interface{}, string, string) {
 	// first check if we allow cml override, and if we do, try to get it from there
-	if jcp.options.CmlPropertyOverride {
-		if v := CmlArgumentsProvider().Get(jcp.options.CmlPropertyOverrideSwitch+variableName, nil); v != nil {
-			return v
+	for _, name := range names {
+		if v, location := jcp.cmlOverride(name); v != nil {
+			return v, name, location
 		}
 	}
-	// first split the parcels from the dot notation
-	parcels := strings.Split(variableName, ".")
-	var currentBlock *map[string]interface{}
-	var currentValue interface{} = *jcp.json
-	for _, p := range parcels {
-		if b, isType := currentValue.(map[string]interface{}); !isType {
-			return nil
-		} else {
-			currentBlock = &b
+	for _, name := range names {
+		if v, location := jcp.environmentOverride(name); v != nil {
+			return v, name, location
 		}
-		if v, found := (*currentBlock)[p]; !found {
-			return nil
-		} else {
-			currentValue = v
+	}
+	if loaded, _ := jcp.state(); loaded != nil {
+		for _, name := range names {
+			if value, _ := Lookup(*loaded, name); value != nil {
+				// values of objects and lists are overridden as when they are got by their own path
+				value, _ = overridden(name, value, jcp.override)
+				return value, name, ""
+			}
+		}
+	}
+	return nil, "", ""
+}
+
+// cmlOverride gets the value overriding a property in the command line, if allowed, and the switch setting it
+func (jcp *jsonConfigurationProvider) cmlOverride(path string) (interface{}, string) {
+	if !jcp.options.CmlPropertyOverride {
+		return nil, ""
+	}
+	if v := jcp.arguments().switchValue(jcp.options.CmlPropertyOverrideSwitch + path); v != nil {
+		return v, "switch -" + jcp.options.CmlPropertyOverrideSwitch + path
+	}
+	return nil, ""
+}
+
+// environmentOverride gets the value overriding a property in an environment variable, if allowed, and the variable
+// setting it
+func (jcp *jsonConfigurationProvider) environmentOverride(path string) (interface{}, string) {
+	jcp.lock.Lock()
+	envOverride, envPrefix := jcp.options.EnvPropertyOverride, jcp.options.EnvPropertyOverridePrefix
+	jcp.lock.Unlock()
+	if !envOverride {
+		return nil, ""
+	}
+	variableName := envPrefix + environmentName(path)
+	if v, found := jcp.variables().lookup(variableName); found {
+		return v, "environment variable " + variableName
+	}
+	return nil, ""
+}
+
+// override gets the value overriding a property, in the command line or in an environment variable, if any
+func (jcp *jsonConfigurationProvider) override(path string) (interface{}, bool) {
+	if v, _ := jcp.cmlOverride(path); v != nil {
+		return v, true
+	}
+	if v, _ := jcp.environmentOverride(path); v != nil {
+		return v, true
+	}
+	return nil, false
+}
+
+// locate describes the property where the variable is found, or the ones where it's looked for, which is the cml
+// switch or environment variable overriding it if it's set
+func (jcp *jsonConfigurationProvider) locate(name string, config interface{}) string {
+	names := jcp.variableNames(name, config)
+	_, found, override := jcp.find(names)
+	if override != "" {
+		return override
+	}
+	variableName := found
+	if found == "" {
+		variableName = strings.Join(names, " or ")
+	}
+	jcp.lock.Lock()
+	defer jcp.lock.Unlock()
+	if jcp.options.Filename == "" {
+		return "property " + variableName + " (no json file)"
+	}
+	if len(jcp.files) > 1 {
+		return "property " + variableName + " in " + strings.Join(jcp.files, " + ")
+	}
+	return "property " + variableName + " in " + jcp.options.Filename
+}
+
+// state gets the loaded json configuration, nil if none is loaded, and the configured file
+func (jcp *jsonConfigurationProvider) state() (*map[string]interface{}, string) {
+	jcp.lock.Lock()
+	defer jcp.lock.Unlock()
+	return jcp.json, jcp.options.Filename
+}
+
+// DependsOn
+// The json provider gets its filename and the active profiles from the command line.
+func (jcp *jsonConfigurationProvider) DependsOn() []Provider {
+	return []Provider{jcp.arguments()}
+}
+
+// profiles gets the active profiles whose files overlay the configuration, none if they are ignored
+func (jcp *jsonConfigurationProvider) profiles() []string {
+	if jcp.options.IgnoreProfiles {
+		return nil
+	}
+	selector := jcp.selector
+	if selector == nil {
+		selector = env.profiles
+	}
+	return selector.active(jcp.arguments(), jcp.variables())
+}
+
+// arguments gets the cml provider the filename, the active profiles and the overrides are taken from
+func (jcp *jsonConfigurationProvider) arguments() *cmlArgumentsProvider {
+	if jcp.cml != nil {
+		return jcp.cml
+	}
+	return CmlArgumentsProvider()
+}
+
+// Name
+// Identifies the provider as json.
+func (jcp *jsonConfigurationProvider) Name() string {
+	return "json"
+}
+
+// Description
+// Describes the configuration files read by the provider.
+func (jcp *jsonConfigurationProvider) Description() string {
+	jcp.lock.Lock()
+	defer jcp.lock.Unlock()
+	if jcp.options.Filename == "" {
+		return "no file"
+	}
+	if len(jcp.files) > 0 {
+		return "file " + strings.Join(jcp.files, " + ")
+	}
+	return "file " + jcp.options.Filename
+}
+
+// Keys
+// Gets the names of the properties of the json configuration under the given prefix, including the ones overridden in
+// the command line.
+func (jcp *jsonConfigurationProvider) Keys(prefix string) []string {
+	jcp.lock.Lock()
+	if jcp.json == nil {
+		jcp.lock.Unlock()
+		return []string{}
+	}
+	names := flatten("", *jcp.json, nil)
+	jcp.lock.Unlock()
+
+	if jcp.options.CmlPropertyOverride {
+		for _, name := range jcp.arguments().Keys("") {
+			if strings.HasPrefix(name, jcp.options.CmlPropertyOverrideSwitch) && len(name) > len(jcp.options.CmlPropertyOverrideSwitch) {
+				names = append(names, name[len(jcp.options.CmlPropertyOverrideSwitch):])
+			}
 		}
+	}
+	return filterKeys(names, prefix)
+}
 
+// variables gets the environment variables provider the overrides are taken from
+func (jcp *jsonConfigurationProvider) variables() *environmentVariablesProvider {
+	if jcp.environment != nil {
+		return jcp.environment
 	}
-	return currentValue
+	return EnvironmentVariablesProvider()
 }
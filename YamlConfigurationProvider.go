@@ -7,7 +7,6 @@ package env
 import (
 	"io/ioutil"
 	"log"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -18,8 +17,14 @@ import (
 type yamlConfigurationProvider struct {
 	options   YamlConfigurationProviderOptions
 	timestamp time.Time
+	files     []string
 	lock      sync.Mutex
-	yaml      *map[interface{}]interface{}
+	// cml, environment and selector are the providers and profile selection of the environment the provider belongs
+	// to, the default environment ones if nil
+	cml         *cmlArgumentsProvider
+	environment *environmentVariablesProvider
+	selector    *profileSelector
+	yaml        *map[interface{}]interface{}
 }
 
 type yamlConfigurationSource struct {
@@ -46,6 +51,14 @@ type YamlConfigurationProviderOptions struct {
 	CmlPropertyOverride       bool
 	CmlPropertyOverrideSwitch string
 	Filename                  string
+	// IgnoreProfiles disables the overlay of the configuration files of the active profiles
+	IgnoreProfiles bool
+	// Naming is the strategy giving the property names of variables (see RelaxedNaming)
+	Naming NamingStrategy
+	// EnvPropertyOverride allows properties to be overridden by environment variables named after them, with dots and
+	// list indices as underscores and preceded by EnvPropertyOverridePrefix (APP_SERVERS_0_HOST for servers[0].host)
+	EnvPropertyOverride       bool
+	EnvPropertyOverridePrefix string
 }
 
 var defaultYamlConfigurationProviderOptions = YamlConfigurationProviderOptions{
@@ -53,33 +66,32 @@ var defaultYamlConfigurationProviderOptions = YamlConfigurationProviderOptions{
 	CmlSwitch:                 "y",
 	CmlPropertyOverride:       true,
 	CmlPropertyOverrideSwitch: "Y",
+	EnvPropertyOverridePrefix: "APP_",
 }
 
-var yamlConfigurationProviderDefaultInstance *yamlConfigurationProvider
-var ycpMutex = sync.Mutex{}
-
 // YamlConfigurationProvider
-// Gets or creates the default YAML configuration Provider instance (Singleton) with default Options
+// Gets the YAML configuration Provider of the default environment
 func YamlConfigurationProvider() *yamlConfigurationProvider {
-	if yamlConfigurationProviderDefaultInstance == nil {
-		return YamlConfigurationProviderWithOptions(defaultYamlConfigurationProviderOptions)
-	}
-	return yamlConfigurationProviderDefaultInstance
-
+	return env.YamlConfigurationProvider()
 }
 
 // YamlConfigurationProviderWithOptions
-// Gets or creates the default JSON Configuration Provider instance (Singleton) with given Options. Options are
-// ignored if there is already a default instance initialized
+// Gets the YAML configuration Provider of the default environment. Options are ignored as the provider is created with
+// the environment, environments with other options are created with New (see Settings)
 func YamlConfigurationProviderWithOptions(options YamlConfigurationProviderOptions) *yamlConfigurationProvider {
-	if yamlConfigurationProviderDefaultInstance == nil {
-		ycpMutex.Lock() // lock only for the moment where the default instance might be updated
-		if yamlConfigurationProviderDefaultInstance == nil {
-			yamlConfigurationProviderDefaultInstance = NewYamlConfigurationProviderWithOptions(options)
-		}
-		ycpMutex.Unlock()
-	}
-	return yamlConfigurationProviderDefaultInstance
+	return env.YamlConfigurationProvider()
+}
+
+// YamlConfigurationProvider
+// Gets the YAML configuration Provider of the environment
+func (en *Environment) YamlConfigurationProvider() *yamlConfigurationProvider {
+	return en.yamlProvider
+}
+
+// YamlConfigurationSource
+// Creates a source for variables provided by the YAML configuration Provider of the environment
+func (en *Environment) YamlConfigurationSource() *yamlConfigurationSource {
+	return en.yamlProvider.Source()
 }
 
 // NewYamlConfigurationProvider
@@ -91,16 +103,31 @@ func NewYamlConfigurationProvider() *yamlConfigurationProvider {
 // NewYamlConfigurationProviderWithOptions
 // Creates a new Yaml configuration Provider with given options
 func NewYamlConfigurationProviderWithOptions(options YamlConfigurationProviderOptions) *yamlConfigurationProvider {
+	return newYamlConfigurationProvider(options, nil, nil, nil)
+}
+
+// newYamlConfigurationProvider creates a YAML configuration Provider using the given cml and environment variables
+// providers and profile selection
+func newYamlConfigurationProvider(options YamlConfigurationProviderOptions, cml *cmlArgumentsProvider, environment *environmentVariablesProvider, profiles *profileSelector) *yamlConfigurationProvider {
 	ycp := &yamlConfigurationProvider{
-		options: options,
+		options:     options,
+		cml:         cml,
+		environment: environment,
+		selector:    profiles,
 	}
 	_ = ycp.Load()
 	return ycp
 }
 
 func YamlConfigurationSource() *yamlConfigurationSource {
+	return env.YamlConfigurationSource()
+}
+
+// Source
+// Creates a source for variables provided by this provider instance
+func (ycp *yamlConfigurationProvider) Source() *yamlConfigurationSource {
 	return &yamlConfigurationSource{
-		provider: YamlConfigurationProvider(),
+		provider: ycp,
 	}
 }
 
@@ -108,41 +135,61 @@ func YamlConfigurationSource() *yamlConfigurationSource {
 // Loads the yaml configuration file. This is the only time when the filename is
 // resolved as the source is not expected to change for a refresh.
 func (ycp *yamlConfigurationProvider) Load() error {
-	if ycp.options.FileFromCml {
-		if v := CmlArgumentsProvider().Get(ycp.options.CmlSwitch, nil); v != nil {
-			ycp.options.Filename = v.(string)
-		} else {
-			ycp.options.Filename = ""
+	ycp.lock.Lock()
+	fileFromCml, cmlSwitch := ycp.options.FileFromCml, ycp.options.CmlSwitch
+	ycp.lock.Unlock()
+	filename := ""
+	if fileFromCml {
+		if v := ycp.arguments().Get(cmlSwitch, nil); v != nil {
+			filename = v.(string)
 		}
 	}
+	ycp.lock.Lock()
+	if fileFromCml {
+		ycp.options.Filename = filename
+	}
+	ycp.timestamp = time.Time{} // forces the files to be read again
+	ycp.files = nil
+	ycp.lock.Unlock()
 	_, e := ycp.Refresh()
 	return e
 }
 
 // Refresh
-// Reloads the configuration file. If no yaml file is configured, it is a nil operation.
+// Reloads the configuration file, overlaid with the files of the active profiles (see ActiveProfiles), if any of them
+// changed. Files are only read again when the latest modification time of the files is later than the one of the
+// files last read, or when the files to read change (like when profiles are activated), and the refresh reports no
+// update otherwise. Files rewritten with the same modification time (as by tools preserving times or in file systems
+// with a coarse time resolution) are not reloaded. If no yaml file is configured, it is a nil operation.
 func (ycp *yamlConfigurationProvider) Refresh() (bool, error) {
-	if ycp.options.Filename != "" {
-		stat, e := os.Stat(ycp.options.Filename)
+	if _, filename := ycp.state(); filename != "" {
+		files, timestamp, e := configurationFiles(filename, ycp.profiles())
 		if e != nil {
 			return false, e
 		}
 		ycp.lock.Lock()
 		defer ycp.lock.Unlock()
-		if !stat.ModTime().After(ycp.timestamp) {
+		if !timestamp.After(ycp.timestamp) && equal(files, ycp.files) {
 			return false, nil
 		}
-		if b, e := ioutil.ReadFile(ycp.options.Filename); e != nil {
-			log.Printf("Unable to read yaml file : \"%v\"", e)
-			return false, e
-		} else {
-			yamlObject := make(map[interface{}]interface{})
-			if e = yaml.Unmarshal(b, &yamlObject); e != nil {
+		var yamlObject interface{}
+		for _, file := range files {
+			if b, e := ioutil.ReadFile(file); e != nil {
+				log.Printf("%s: unable to read file : \"%v\"", ycp.Name(), e)
 				return false, e
+			} else {
+				fileObject := make(map[interface{}]interface{})
+				if e = yaml.Unmarshal(b, &fileObject); e != nil {
+					return false, e
+				}
+				yamlObject = mergeValues(yamlObject, fileObject, MergeOptions{})
 			}
-			ycp.yaml = &yamlObject
-			return true, nil
 		}
+		merged := yamlObject.(map[interface{}]interface{})
+		ycp.yaml = &merged
+		ycp.files = files
+		ycp.timestamp = timestamp
+		return true, nil
 	}
 	return false, nil
 }
@@ -151,42 +198,230 @@ func (ycp *yamlConfigurationProvider) Refresh() (bool, error) {
 // Gets the given property if available.
 func (ycp *yamlConfigurationProvider) Get(name string, config interface{}) interface{} {
 	// If no yaml has been loaded, let's just return nil
-	if ycp.yaml == nil {
+	if loaded, _ := ycp.state(); loaded == nil {
 		return nil
 	}
 
-	variableName := name
+	value, _, _ := ycp.find(ycp.variableNames(name, config))
+	return value
+}
+
+// Lookup
+// Gets the value at the given path of the yaml configuration (see Lookup), failing if the path is not valid or walks
+// into a scalar value. Cml overrides are not considered.
+func (ycp *yamlConfigurationProvider) Lookup(path string) (interface{}, error) {
+	ycp.lock.Lock()
+	defer ycp.lock.Unlock()
+	if ycp.yaml == nil {
+		return nil, nil
+	}
+	return Lookup(*ycp.yaml, path)
+}
+
+// UseNaming
+// Sets the strategy giving the property names of variables (see RelaxedNaming). Variables are looked for by their own
+// name if no strategy is set.
+func (ycp *yamlConfigurationProvider) UseNaming(strategy NamingStrategy) *yamlConfigurationProvider {
+	ycp.lock.Lock()
+	ycp.options.Naming = strategy
+	ycp.lock.Unlock()
+	return ycp
+}
+
+// variableNames gets the property names for a variable, which may be configured in its source
+func (ycp *yamlConfigurationProvider) variableNames(name string, config interface{}) []string {
 	// let's check if a configuration is passed and if it's the right type
 	if config != nil {
 		if source, isType := config.(*yamlConfigurationSource); isType {
 			if source.name != nil {
-				variableName = *source.name
+				return []string{*source.name}
 			}
 		}
 	}
+	ycp.lock.Lock()
+	naming := ycp.options.Naming
+	ycp.lock.Unlock()
+	return namesOf(naming, name)
+}
+
+// UseEnvironmentOverrides
+// Allows properties to be overridden by environment variables named after them with the given prefix (see
+// EnvPropertyOverride).
+func (ycp *yamlConfigurationProvider) UseEnvironmentOverrides(prefix string) *yamlConfigurationProvider {
+	ycp.lock.Lock()
+	ycp.options.EnvPropertyOverride = true
+	ycp.options.EnvPropertyOverridePrefix = prefix
+	ycp.lock.Unlock()
+	return ycp
+}
 
+// find gets the value of the first of the given property names provided, with the name it was found with and where
+// it was overridden, if it was. Properties overridden in the command line are found first, then the ones overridden by
+// environment variables, if overrides are allowed. Objects and lists are found with their overridden values replaced.
+func (ycp *yamlConfigurationProvider) find(names []string) (interface{}, string, string) {
 	// first check if we allow cml override, and if we do, try to get it from there
-	if ycp.options.CmlPropertyOverride {
-		if v := CmlArgumentsProvider().Get(ycp.options.CmlPropertyOverrideSwitch+variableName, nil); v != nil {
-			return v
+	for _, name := range names {
+		if v, location := ycp.cmlOverride(name); v != nil {
+			return v, name, location
 		}
 	}
-	// first split the parcels from the dot notation
-	parcels := strings.Split(variableName, ".")
-	var currentBlock *map[interface{}]interface{}
-	var currentValue interface{} = *ycp.yaml
-	for _, p := range parcels {
-		if b, isType := currentValue.(map[interface{}]interface{}); !isType {
-			return nil
-		} else {
-			currentBlock = &b
+	for _, name := range names {
+		if v, location := ycp.environmentOverride(name); v != nil {
+			return v, name, location
 		}
-		if v, found := (*currentBlock)[p]; !found {
-			return nil
-		} else {
-			currentValue = v
+	}
+	if loaded, _ := ycp.state(); loaded != nil {
+		for _, name := range names {
+			if value, _ := Lookup(*loaded, name); value != nil {
+				// values of objects and lists are overridden as when they are got by their own path
+				value, _ = overridden(name, value, ycp.override)
+				return value, name, ""
+			}
+		}
+	}
+	return nil, "", ""
+}
+
+// cmlOverride gets the value overriding a property in the command line, if allowed, and the switch setting it
+func (ycp *yamlConfigurationProvider) cmlOverride(path string) (interface{}, string) {
+	if !ycp.options.CmlPropertyOverride {
+		return nil, ""
+	}
+	if v := ycp.arguments().switchValue(ycp.options.CmlPropertyOverrideSwitch + path); v != nil {
+		return v, "switch -" + ycp.options.CmlPropertyOverrideSwitch + path
+	}
+	return nil, ""
+}
+
+// environmentOverride gets the value overriding a property in an environment variable, if allowed, and the variable
+// setting it
+func (ycp *yamlConfigurationProvider) environmentOverride(path string) (interface{}, string) {
+	ycp.lock.Lock()
+	envOverride, envPrefix := ycp.options.EnvPropertyOverride, ycp.options.EnvPropertyOverridePrefix
+	ycp.lock.Unlock()
+	if !envOverride {
+		return nil, ""
+	}
+	variableName := envPrefix + environmentName(path)
+	if v, found := ycp.variables().lookup(variableName); found {
+		return v, "environment variable " + variableName
+	}
+	return nil, ""
+}
+
+// override gets the value overriding a property, in the command line or in an environment variable, if any
+func (ycp *yamlConfigurationProvider) override(path string) (interface{}, bool) {
+	if v, _ := ycp.cmlOverride(path); v != nil {
+		return v, true
+	}
+	if v, _ := ycp.environmentOverride(path); v != nil {
+		return v, true
+	}
+	return nil, false
+}
+
+// locate describes the property where the variable is found, or the ones where it's looked for, which is the cml
+// switch or environment variable overriding it if it's set
+func (ycp *yamlConfigurationProvider) locate(name string, config interface{}) string {
+	names := ycp.variableNames(name, config)
+	_, found, override := ycp.find(names)
+	if override != "" {
+		return override
+	}
+	variableName := found
+	if found == "" {
+		variableName = strings.Join(names, " or ")
+	}
+	ycp.lock.Lock()
+	defer ycp.lock.Unlock()
+	if ycp.options.Filename == "" {
+		return "property " + variableName + " (no yaml file)"
+	}
+	if len(ycp.files) > 1 {
+		return "property " + variableName + " in " + strings.Join(ycp.files, " + ")
+	}
+	return "property " + variableName + " in " + ycp.options.Filename
+}
+
+// state gets the loaded yaml configuration, nil if none is loaded, and the configured file
+func (ycp *yamlConfigurationProvider) state() (*map[interface{}]interface{}, string) {
+	ycp.lock.Lock()
+	defer ycp.lock.Unlock()
+	return ycp.yaml, ycp.options.Filename
+}
+
+// DependsOn
+// The yaml provider gets its filename and the active profiles from the command line.
+func (ycp *yamlConfigurationProvider) DependsOn() []Provider {
+	return []Provider{ycp.arguments()}
+}
+
+// profiles gets the active profiles whose files overlay the configuration, none if they are ignored
+func (ycp *yamlConfigurationProvider) profiles() []string {
+	if ycp.options.IgnoreProfiles {
+		return nil
+	}
+	selector := ycp.selector
+	if selector == nil {
+		selector = env.profiles
+	}
+	return selector.active(ycp.arguments(), ycp.variables())
+}
+
+// arguments gets the cml provider the filename, the active profiles and the overrides are taken from
+func (ycp *yamlConfigurationProvider) arguments() *cmlArgumentsProvider {
+	if ycp.cml != nil {
+		return ycp.cml
+	}
+	return CmlArgumentsProvider()
+}
+
+// Name
+// Identifies the provider as yaml.
+func (ycp *yamlConfigurationProvider) Name() string {
+	return "yaml"
+}
+
+// Description
+// Describes the configuration files read by the provider.
+func (ycp *yamlConfigurationProvider) Description() string {
+	ycp.lock.Lock()
+	defer ycp.lock.Unlock()
+	if ycp.options.Filename == "" {
+		return "no file"
+	}
+	if len(ycp.files) > 0 {
+		return "file " + strings.Join(ycp.files, " + ")
+	}
+	return "file " + ycp.options.Filename
+}
+
+// Keys
+// Gets the names of the properties of the yaml configuration under the given prefix, including the ones overridden in
+// the command line.
+func (ycp *yamlConfigurationProvider) Keys(prefix string) []string {
+	ycp.lock.Lock()
+	if ycp.yaml == nil {
+		ycp.lock.Unlock()
+		return []string{}
+	}
+	names := flatten("", *ycp.yaml, nil)
+	ycp.lock.Unlock()
+
+	if ycp.options.CmlPropertyOverride {
+		for _, name := range ycp.arguments().Keys("") {
+			if strings.HasPrefix(name, ycp.options.CmlPropertyOverrideSwitch) && len(name) > len(ycp.options.CmlPropertyOverrideSwitch) {
+				names = append(names, name[len(ycp.options.CmlPropertyOverrideSwitch):])
+			}
 		}
+	}
+	return filterKeys(names, prefix)
+}
 
+// variables gets the environment variables provider the overrides are taken from
+func (ycp *yamlConfigurationProvider) variables() *environmentVariablesProvider {
+	if ycp.environment != nil {
+		return ycp.environment
 	}
-	return currentValue
+	return EnvironmentVariablesProvider()
 }
@@ -0,0 +1,170 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"time"
+
+	"github.com/gomatbase/go-error"
+)
+
+const (
+	ErrInvalidConversion = err.ErrorF("Unable to convert variable %s (from %s) to %s: %v")
+)
+
+// getConverted
+// Gets the value of a variable converted by the given converter. A nil value is returned with no error if the
+// variable is not provided. Values of secret variables are given as they are, not wrapped.
+func (en *Environment) getConverted(name string, scoped bool, typeName string, converter func(value interface{}) (interface{}, error)) (interface{}, error) {
+	return en.getConvertedFrom(name, scoped, typeName, func(value interface{}, _ Source) (interface{}, error) {
+		return converter(value)
+	})
+}
+
+// getConvertedFrom gets the value of a variable converted by a converter depending on the source providing it. The
+// variable is resolved with the names mapped by a scope if scoped.
+func (en *Environment) getConvertedFrom(name string, scoped bool, typeName string, converter func(value interface{}, s Source) (interface{}, error)) (interface{}, error) {
+	value, s, _ := en.resolve(name, nil, scoped)
+	if value == nil {
+		return nil, nil
+	}
+	converted, e := converter(value, s)
+	if e != nil {
+		if en.isSecret(name) {
+			e = maskError(e, value)
+		}
+		return nil, ErrInvalidConversion.WithValues(name, sourceName(s), typeName, e)
+	}
+	return converted, nil
+}
+
+// GetString
+// Gets the value of a variable as a string. Returns an empty string if it's not provided.
+func (en *Environment) GetString(name string) (string, error) {
+	v, e := en.getConverted(name, false, "string", asString)
+	if v == nil {
+		return "", e
+	}
+	return v.(string), nil
+}
+
+// GetInt
+// Gets the value of a variable as an int. Returns 0 if it's not provided.
+func (en *Environment) GetInt(name string) (int, error) {
+	v, e := en.getConverted(name, false, "int", asInt)
+	if v == nil {
+		return 0, e
+	}
+	return v.(int), nil
+}
+
+// GetInt64
+// Gets the value of a variable as an int64. Returns 0 if it's not provided.
+func (en *Environment) GetInt64(name string) (int64, error) {
+	v, e := en.getConverted(name, false, "int64", asInt64)
+	if v == nil {
+		return 0, e
+	}
+	return v.(int64), nil
+}
+
+// GetFloat
+// Gets the value of a variable as a float64. Returns 0 if it's not provided.
+func (en *Environment) GetFloat(name string) (float64, error) {
+	v, e := en.getConverted(name, false, "float64", asFloat)
+	if v == nil {
+		return 0, e
+	}
+	return v.(float64), nil
+}
+
+// GetBool
+// Gets the value of a variable as a bool. Returns false if it's not provided. A command line switch given without
+// a value is taken as true.
+func (en *Environment) GetBool(name string) (bool, error) {
+	v, e := en.getConvertedFrom(name, false, "bool", switchAsBool)
+	if v == nil {
+		return false, e
+	}
+	return v.(bool), nil
+}
+
+// GetDuration
+// Gets the value of a variable as a time.Duration. Returns 0 if it's not provided.
+func (en *Environment) GetDuration(name string) (time.Duration, error) {
+	v, e := en.getConverted(name, false, "time.Duration", asDuration)
+	if v == nil {
+		return 0, e
+	}
+	return v.(time.Duration), nil
+}
+
+// GetStringSlice
+// Gets the value of a variable as a []string. Returns nil if it's not provided.
+func (en *Environment) GetStringSlice(name string) ([]string, error) {
+	v, e := en.getConverted(name, false, "[]string", asStringSlice)
+	if v == nil {
+		return nil, e
+	}
+	return v.([]string), nil
+}
+
+// GetStringMap
+// Gets the value of a variable as a map[string]interface{}. Returns nil if it's not provided.
+func (en *Environment) GetStringMap(name string) (map[string]interface{}, error) {
+	v, e := en.getConverted(name, false, "map[string]interface{}", asStringMap)
+	if v == nil {
+		return nil, e
+	}
+	return v.(map[string]interface{}), nil
+}
+
+// GetString
+// Gets the value of a variable of the default environment (see Environment.GetString).
+func GetString(name string) (string, error) {
+	return env.GetString(name)
+}
+
+// GetInt
+// Gets the value of a variable of the default environment (see Environment.GetInt).
+func GetInt(name string) (int, error) {
+	return env.GetInt(name)
+}
+
+// GetInt64
+// Gets the value of a variable of the default environment (see Environment.GetInt64).
+func GetInt64(name string) (int64, error) {
+	return env.GetInt64(name)
+}
+
+// GetFloat
+// Gets the value of a variable of the default environment (see Environment.GetFloat).
+func GetFloat(name string) (float64, error) {
+	return env.GetFloat(name)
+}
+
+// GetBool
+// Gets the value of a variable of the default environment (see Environment.GetBool).
+func GetBool(name string) (bool, error) {
+	return env.GetBool(name)
+}
+
+// GetDuration
+// Gets the value of a variable of the default environment (see Environment.GetDuration).
+func GetDuration(name string) (time.Duration, error) {
+	return env.GetDuration(name)
+}
+
+// GetStringSlice
+// Gets the value of a variable of the default environment (see Environment.GetStringSlice).
+func GetStringSlice(name string) ([]string, error) {
+	return env.GetStringSlice(name)
+}
+
+// GetStringMap
+// Gets the value of a variable of the default environment (see Environment.GetStringMap).
+func GetStringMap(name string) (map[string]interface{}, error) {
+	return env.GetStringMap(name)
+}
@@ -0,0 +1,347 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gomatbase/go-error"
+)
+
+const (
+	ErrInvalidBindTarget = err.Error("Bind target must be a non-nil pointer to a struct.")
+	ErrUnknownSource     = err.ErrorF("Unknown source %s for variable %s")
+)
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	secretValueType = reflect.TypeOf(SecretValue{})
+)
+
+// sources which may be referred to in the source tag of bound struct fields
+var sourceFactories = map[string]func(en *Environment) Source{
+	"cml":    func(en *Environment) Source { return en.CmlArgumentsSource() },
+	"json":   func(en *Environment) Source { return en.JsonConfigurationSource() },
+	"yaml":   func(en *Environment) Source { return en.YamlConfigurationSource() },
+	"dotenv": func(en *Environment) Source { return en.DotEnvConfigurationSource() },
+	"env":    func(en *Environment) Source { return en.EnvironmentVariablesSource() },
+}
+
+// Bind
+// Binds the exported fields of the struct pointed by target to variables and sets them with the variables values.
+// Fields are configured through tags:
+//
+//	env:"name,required,secret"  the variable name (the field name starting in lower case if omitted) and if it's
+//	                            required or a secret. "-" skips the field. For struct fields the name is the prefix
+//	                            of the nested fields. Secret values are only kept wrapped in SecretValue fields.
+//	default:"value"             the default value, converted to the field type.
+//	source:"yaml,env"           the sources of the variable in priority order (cml, json, yaml, dotenv or env).
+//	                            Default chain if omitted.
+//
+// Variables already added are reused as they are. Fields for variables which are not provided keep their values.
+// All the failures are returned aggregated.
+func (en *Environment) Bind(target interface{}) error {
+	_, e := en.bind(target, "", false)
+	return e
+}
+
+// bind
+// Binds the target with variable names under the given prefix, returning the names of the variables bound to it.
+// Variables of a scope are bound with their names mapped (see Scope).
+func (en *Environment) bind(target interface{}, prefix string, scoped bool) ([]string, error) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, ErrInvalidBindTarget
+	}
+
+	errors := err.Errors()
+	names := en.bindStruct(rv.Elem(), prefix, scoped, errors)
+	if errors.Count() > 0 {
+		if en.settings.FailOnMissingRequired {
+			panic(errors)
+		}
+		return names, errors
+	}
+	return names, nil
+}
+
+// bindStruct
+// Registers and sets the fields of a struct, returning the names of the variables bound to it
+func (en *Environment) bindStruct(target reflect.Value, prefix string, scoped bool, errors err.IErrors) []string {
+	var names []string
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		tag, tagged := field.Tag.Lookup("env")
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+		name, required, secret := parseEnvTag(tag, field.Name)
+		fieldValue := target.Field(i)
+
+		if nested, isStruct := structTarget(fieldValue); isStruct {
+			if field.Anonymous && !tagged {
+				names = append(names, en.bindStruct(nested, prefix, scoped, errors)...)
+			} else {
+				names = append(names, en.bindStruct(nested, prefix+name+".", scoped, errors)...)
+			}
+			continue
+		}
+
+		name = prefix + name
+		names = append(names, name)
+		if e := en.registerField(name, scoped, required, secret, field.Tag); e != nil {
+			errors.AddError(e)
+			continue
+		}
+		value, s, e := en.resolve(name, nil, scoped)
+		if e != nil {
+			errors.AddError(e)
+			continue
+		}
+		if value == nil {
+			if required {
+				errors.AddError(err.Error("Property " + name + " not provided!"))
+			}
+			continue
+		}
+		if e := decode(value, fieldValue); e != nil {
+			if en.isSecret(name) {
+				e = maskError(e, value)
+			}
+			errors.AddError(ErrInvalidConversion.WithValues(name, sourceName(s), fieldValue.Type(), e))
+		}
+	}
+	return names
+}
+
+// registerField adds the variable for a field, unless a variable with the same name was already added
+func (en *Environment) registerField(name string, scoped bool, required bool, secret bool, tag reflect.StructTag) error {
+	en.lock.Lock()
+	_, found := en.variables[name]
+	en.lock.Unlock()
+	if found {
+		return nil
+	}
+
+	v := en.Var(name)
+	v.scoped = scoped
+	if required {
+		v.Required()
+	}
+	if secret {
+		v.Secret()
+	}
+	if defaultValue, hasDefault := tag.Lookup("default"); hasDefault {
+		v.Default(defaultValue)
+	}
+	if sources, hasSources := tag.Lookup("source"); hasSources {
+		for _, sourceTag := range strings.Split(sources, ",") {
+			factory, known := sourceFactories[strings.TrimSpace(sourceTag)]
+			if !known {
+				return ErrUnknownSource.WithValues(strings.TrimSpace(sourceTag), name)
+			}
+			v.From(factory(en))
+		}
+	}
+	if e := v.Add(); e != nil && e != ErrVariableAlreadyExists {
+		return e
+	}
+	return nil
+}
+
+// parseEnvTag gets the variable name, required and secret flags from an env tag
+func parseEnvTag(tag string, fieldName string) (string, bool, bool) {
+	parcels := strings.Split(tag, ",")
+	name := strings.TrimSpace(parcels[0])
+	if name == "" {
+		name = lowerFirst(fieldName)
+	}
+	required, secret := false, false
+	for _, option := range parcels[1:] {
+		switch strings.TrimSpace(option) {
+		case "required":
+			required = true
+		case "secret":
+			secret = true
+		}
+	}
+	return name, required, secret
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
+// structTarget checks if a value is a struct (or a pointer to one, which is allocated if nil) to be bound field by
+// field, returning the struct value.
+func structTarget(target reflect.Value) (reflect.Value, bool) {
+	if target.Type() == secretValueType || target.Kind() == reflect.Ptr && target.Type().Elem() == secretValueType {
+		return target, false
+	}
+	if target.Kind() == reflect.Ptr && target.Type().Elem().Kind() == reflect.Struct {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return target.Elem(), true
+	}
+	return target, target.Kind() == reflect.Struct
+}
+
+// decode
+// Sets target with the given value converted to the target type. Lists are decoded into slices, and objects into maps
+// or structs, recursively.
+func decode(value interface{}, target reflect.Value) error {
+	if value == nil {
+		return nil
+	}
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return decode(value, target.Elem())
+	}
+
+	var converted interface{}
+	var e error
+	switch {
+	case target.Type() == secretValueType:
+		converted = SecretValue{value: unwrap(value)}
+	case target.Type() == durationType:
+		converted, e = asDuration(value)
+	case target.Kind() == reflect.String:
+		converted, e = asString(value)
+	case target.Kind() == reflect.Bool:
+		converted, e = asBool(value)
+	case target.Kind() >= reflect.Int && target.Kind() <= reflect.Int64:
+		if converted, e = asInt64(value); e == nil && target.OverflowInt(converted.(int64)) {
+			e = fmt.Errorf("%v overflows %v", converted, target.Type())
+		}
+	case target.Kind() >= reflect.Uint && target.Kind() <= reflect.Uint64:
+		if converted, e = asInt64(value); e == nil {
+			if i := converted.(int64); i < 0 || target.OverflowUint(uint64(i)) {
+				e = fmt.Errorf("%v overflows %v", converted, target.Type())
+			} else {
+				converted = uint64(i)
+			}
+		}
+	case target.Kind() == reflect.Float32 || target.Kind() == reflect.Float64:
+		converted, e = asFloat(value)
+	case target.Kind() == reflect.Interface:
+		converted = normalize(value)
+	case target.Kind() == reflect.Slice:
+		return decodeSlice(value, target)
+	case target.Kind() == reflect.Map:
+		return decodeMap(value, target)
+	case target.Kind() == reflect.Struct:
+		return decodeStruct(value, target)
+	default:
+		return fmt.Errorf("unsupported type %v", target.Type())
+	}
+	if e != nil {
+		return e
+	}
+	target.Set(reflect.ValueOf(converted).Convert(target.Type()))
+	return nil
+}
+
+func decodeSlice(value interface{}, target reflect.Value) error {
+	var elements []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		elements = v
+	case string:
+		parcels, _ := asStringSlice(v)
+		for _, p := range parcels.([]string) {
+			elements = append(elements, p)
+		}
+	default:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice {
+			return fmt.Errorf("unsupported type %T", value)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			elements = append(elements, rv.Index(i).Interface())
+		}
+	}
+
+	result := reflect.MakeSlice(target.Type(), len(elements), len(elements))
+	for i, element := range elements {
+		if e := decode(element, result.Index(i)); e != nil {
+			return fmt.Errorf("element %d: %v", i, e)
+		}
+	}
+	target.Set(result)
+	return nil
+}
+
+func decodeMap(value interface{}, target reflect.Value) error {
+	if target.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("unsupported type %v", target.Type())
+	}
+	m, e := asStringMap(value)
+	if e != nil {
+		return e
+	}
+
+	result := reflect.MakeMap(target.Type())
+	for key, element := range m.(map[string]interface{}) {
+		elementValue := reflect.New(target.Type().Elem()).Elem()
+		if e := decode(element, elementValue); e != nil {
+			return fmt.Errorf("key %s: %v", key, e)
+		}
+		result.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), elementValue)
+	}
+	target.Set(result)
+	return nil
+}
+
+func decodeStruct(value interface{}, target reflect.Value) error {
+	m, e := asStringMap(value)
+	if e != nil {
+		return e
+	}
+	object := m.(map[string]interface{})
+
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		tag := field.Tag.Get("env")
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+		name, _, _ := parseEnvTag(tag, field.Name)
+		element, found := object[name]
+		if !found {
+			for key, v := range object {
+				if strings.EqualFold(key, name) {
+					element, found = v, true
+					break
+				}
+			}
+		}
+		if !found {
+			if defaultValue, hasDefault := field.Tag.Lookup("default"); hasDefault {
+				element, found = defaultValue, true
+			}
+		}
+		if found && element != nil {
+			if e := decode(element, target.Field(i)); e != nil {
+				return fmt.Errorf("field %s: %v", name, e)
+			}
+		}
+	}
+	return nil
+}
+
+// Bind
+// Binds the target to variables of the default environment (see Environment.Bind).
+func Bind(target interface{}) error {
+	return env.Bind(target)
+}
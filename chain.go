@@ -0,0 +1,165 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"github.com/gomatbase/go-error"
+)
+
+const (
+	ErrUnknownProvider = err.ErrorF("Provider %s is not one of the default sources")
+)
+
+type providerSource struct {
+	provider Provider
+}
+
+func (ps *providerSource) Provider() Provider {
+	return ps.provider
+}
+
+func (ps *providerSource) Config() interface{} {
+	return nil
+}
+
+// SourceOf
+// Creates a source for variables provided by the given provider, with no specific configuration.
+func SourceOf(p Provider) Source {
+	return &providerSource{provider: p}
+}
+
+// RegisterProvider
+// Registers a provider in the environment, to be loaded and refreshed with it, and adds it to the default sources at
+// the given position (0 being the highest priority). A negative or out of range position adds it last. Variables added
+// before keep the default sources they were given.
+func (en *Environment) RegisterProvider(p Provider, position int) {
+	_ = en.insert(SourceOf(p), func([]Source) (int, error) {
+		return position, nil
+	})
+}
+
+// AddFirst
+// Adds a source to the default sources with the highest priority. If the source provider already is one of the
+// default sources, it is moved.
+func (en *Environment) AddFirst(s Source) {
+	_ = en.insert(s, func([]Source) (int, error) {
+		return 0, nil
+	})
+}
+
+// AddLast
+// Adds a source to the default sources with the lowest priority. If the source provider already is one of the
+// default sources, it is moved.
+func (en *Environment) AddLast(s Source) {
+	_ = en.insert(s, func([]Source) (int, error) {
+		return -1, nil
+	})
+}
+
+// AddBefore
+// Adds a source to the default sources with a higher priority than the source of the reference provider. If the
+// source provider already is one of the default sources, it is moved.
+func (en *Environment) AddBefore(reference Provider, s Source) error {
+	return en.insert(s, func(sources []Source) (int, error) {
+		return indexOf(sources, reference)
+	})
+}
+
+// AddAfter
+// Adds a source to the default sources with a lower priority than the source of the reference provider. If the
+// source provider already is one of the default sources, it is moved.
+func (en *Environment) AddAfter(reference Provider, s Source) error {
+	return en.insert(s, func(sources []Source) (int, error) {
+		i, e := indexOf(sources, reference)
+		return i + 1, e
+	})
+}
+
+// Providers
+// Gets the providers registered in the environment, in registration order.
+func (en *Environment) Providers() []Provider {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	return append([]Provider{}, en.order...)
+}
+
+// DefaultSources
+// Gets the default sources of the environment, in priority order.
+func (en *Environment) DefaultSources() []Source {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	return append([]Source{}, en.settings.DefaultSources...)
+}
+
+// insert adds a source to the default sources at the position given by the locator, once any other source of the same
+// provider is removed
+func (en *Environment) insert(s Source, locate func(sources []Source) (int, error)) error {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+
+	sources := make([]Source, 0, len(en.settings.DefaultSources)+1)
+	for _, current := range en.settings.DefaultSources {
+		if current.Provider() != s.Provider() {
+			sources = append(sources, current)
+		}
+	}
+	position, e := locate(sources)
+	if e != nil {
+		return e
+	}
+	if position < 0 || position > len(sources) {
+		position = len(sources)
+	}
+	sources = append(sources[:position], append([]Source{s}, sources[position:]...)...)
+
+	en.register(s.Provider())
+	en.settings.DefaultSources = sources
+	return nil
+}
+
+func indexOf(sources []Source, p Provider) (int, error) {
+	for i, s := range sources {
+		if s.Provider() == p {
+			return i, nil
+		}
+	}
+	return 0, ErrUnknownProvider.WithValues(describeProvider(p))
+}
+
+// RegisterProvider
+// Registers a provider in the default environment (see Environment.RegisterProvider).
+func RegisterProvider(p Provider, position int) {
+	env.RegisterProvider(p, position)
+}
+
+// AddFirst
+// Adds a source to the default sources of the default environment with the highest priority.
+func AddFirst(s Source) {
+	env.AddFirst(s)
+}
+
+// AddLast
+// Adds a source to the default sources of the default environment with the lowest priority.
+func AddLast(s Source) {
+	env.AddLast(s)
+}
+
+// AddBefore
+// Adds a source to the default sources of the default environment before the source of the reference provider.
+func AddBefore(reference Provider, s Source) error {
+	return env.AddBefore(reference, s)
+}
+
+// AddAfter
+// Adds a source to the default sources of the default environment after the source of the reference provider.
+func AddAfter(reference Provider, s Source) error {
+	return env.AddAfter(reference, s)
+}
+
+// Providers
+// Gets the providers registered in the default environment.
+func Providers() []Provider {
+	return env.Providers()
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"github.com/gomatbase/go-error"
+)
+
+const (
+	ErrComputationFailure = err.ErrorF("Unable to compute variable %s: %v")
+)
+
+// computedProvider is the pseudo-provider of computed variables. Computed values are not provided by it but by the
+// variables compute functions, it only identifies them as computed.
+type computedProvider struct{}
+
+func (cp *computedProvider) Get(string, interface{}) interface{} {
+	return nil
+}
+
+func (cp *computedProvider) Load() error {
+	return nil
+}
+
+func (cp *computedProvider) Refresh() (bool, error) {
+	return false, nil
+}
+
+func (cp *computedProvider) Name() string {
+	return "computed"
+}
+
+func (cp *computedProvider) Description() string {
+	return "computed variables"
+}
+
+type computedSource struct{}
+
+func (cs *computedSource) Provider() Provider {
+	return computed
+}
+
+func (cs *computedSource) Config() interface{} {
+	return nil
+}
+
+var computed = &computedProvider{}
+var computedValues = &computedSource{}
+
+// Computed
+// Creates a variable whose value is computed from the values of other variables, to be added to the environment.
+// The compute function is given the values of the dependencies by name, nil for the ones not provided, and values of
+// secret variables unwrapped. Computed variables are recomputed by SyncedRefresh whenever a dependency changes,
+// notifying their listener. A converter and a default, applied when the function returns nil, may be set as for any
+// variable.
+//
+//	Computed("db.dsn", []string{"db.host", "db.port"}, func(values map[string]interface{}) interface{} {
+//		return fmt.Sprintf("%v:%v", values["db.host"], values["db.port"])
+//	}).Add()
+func (en *Environment) Computed(name string, dependencies []string, compute func(values map[string]interface{}) interface{}) *variable {
+	v := en.Var(name)
+	v.dependencies = append([]string{}, dependencies...)
+	v.compute = compute
+	return v
+}
+
+// Computed
+// Creates a computed variable to be added to the default environment (see Environment.Computed).
+func Computed(name string, dependencies []string, compute func(values map[string]interface{}) interface{}) *variable {
+	return env.Computed(name, dependencies, compute)
+}
+
+// computeValue computes the value of a computed variable from the current values of its dependencies
+func (en *Environment) computeValue(v *variable, resolving []string) (interface{}, Source, []string, error) {
+	values := make(map[string]interface{}, len(v.dependencies))
+	for _, dependency := range v.dependencies {
+		value, e := en.reference(dependency, resolving)
+		if e != nil {
+			return v.defaults(), nil, v.dependencies, ErrComputationFailure.WithValues(v.name, e)
+		}
+		values[dependency] = value
+	}
+
+	value, e := v.convert(v.compute(values), computedValues)
+	if value == nil {
+		return v.defaults(), nil, v.dependencies, e
+	}
+	return value, computedValues, v.dependencies, nil
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Providers return values in their own native representation: json gives float64 numbers and
+// map[string]interface{} objects, yaml gives int numbers and map[interface{}]interface{} objects, while the command
+// line and environment variables only give strings. The converters below coerce any of those representations into
+// the requested type.
+
+// asString
+// Converts scalar values to their string representation.
+func asString(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case time.Duration:
+		return v.String(), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+// asInt64
+// Converts integer numbers, integral floating point numbers and numeric strings to int64.
+func asInt64(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		i, e := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if e != nil {
+			return nil, e
+		}
+		return i, nil
+	case float64:
+		return floatToInt64(v)
+	case float32:
+		return floatToInt64(float64(v))
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%v overflows int64", value)
+		}
+		return int64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+func floatToInt64(f float64) (interface{}, error) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f > math.MaxInt64 {
+		return nil, fmt.Errorf("%v is not an integer", f)
+	}
+	return int64(f), nil
+}
+
+// asInt
+// Converts to int the same values accepted by asInt64, as long as they fit in an int.
+func asInt(value interface{}) (interface{}, error) {
+	i, e := asInt64(value)
+	if e != nil {
+		return nil, e
+	}
+	if i.(int64) != int64(int(i.(int64))) {
+		return nil, fmt.Errorf("%v overflows int", i)
+	}
+	return int(i.(int64)), nil
+}
+
+// asFloat
+// Converts numbers and numeric strings to float64.
+func asFloat(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case string:
+		f, e := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if e != nil {
+			return nil, e
+		}
+		return f, nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+// asBool
+// Converts booleans, numbers (0 is false) and strings accepted by strconv.ParseBool or being one of yes, no, on, off
+// to bool.
+func asBool(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "yes", "on":
+			return true, nil
+		case "no", "off":
+			return false, nil
+		}
+		b, e := strconv.ParseBool(strings.TrimSpace(v))
+		if e != nil {
+			return nil, e
+		}
+		return b, nil
+	}
+	if f, e := asFloat(value); e == nil {
+		return f.(float64) != 0, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+// switchAsBool
+// Converts values to bool as asBool does, taking the empty value of a command line switch given without a value as
+// true.
+func switchAsBool(value interface{}, s Source) (interface{}, error) {
+	if value == "" && s != nil {
+		if _, isType := s.Provider().(*cmlArgumentsProvider); isType {
+			return true, nil
+		}
+	}
+	return asBool(value)
+}
+
+// asDuration
+// Converts strings accepted by time.ParseDuration to time.Duration. Integer numbers are taken as nanoseconds, as
+// time.Duration itself does.
+func asDuration(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		d, e := time.ParseDuration(strings.TrimSpace(v))
+		if e != nil {
+			return nil, e
+		}
+		return d, nil
+	}
+	i, e := asInt64(value)
+	if e != nil {
+		return nil, e
+	}
+	return time.Duration(i.(int64)), nil
+}
+
+// asStringSlice
+// Converts lists of scalars to []string. Strings are split by commas and any other scalar is taken as a single
+// element list.
+func asStringSlice(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []string:
+		return append([]string{}, v...), nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return []string{}, nil
+		}
+		parcels := strings.Split(v, ",")
+		for i, p := range parcels {
+			parcels[i] = strings.TrimSpace(p)
+		}
+		return parcels, nil
+	case []interface{}:
+		result := make([]string, len(v))
+		for i, element := range v {
+			s, e := asString(element)
+			if e != nil {
+				return nil, fmt.Errorf("element %d: %v", i, e)
+			}
+			result[i] = s.(string)
+		}
+		return result, nil
+	}
+	s, e := asString(value)
+	if e != nil {
+		return nil, e
+	}
+	return []string{s.(string)}, nil
+}
+
+// asStringMap
+// Converts json or yaml objects to map[string]interface{}, normalizing any nested object as well. Strings are parsed
+// as comma separated key=value pairs.
+func asStringMap(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}, map[interface{}]interface{}:
+		return normalize(v), nil
+	case string:
+		result := make(map[string]interface{})
+		if strings.TrimSpace(v) == "" {
+			return result, nil
+		}
+		for _, pair := range strings.Split(v, ",") {
+			i := strings.IndexByte(pair, '=')
+			if i < 0 {
+				return nil, fmt.Errorf("\"%s\" is not a key=value pair", strings.TrimSpace(pair))
+			}
+			result[strings.TrimSpace(pair[:i])] = strings.TrimSpace(pair[i+1:])
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+// normalize
+// Recursively converts yaml objects (map[interface{}]interface{}) into json-like objects (map[string]interface{}).
+// Objects and lists are always copied, other values are returned as they are.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			result[key] = normalize(element)
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			result[fmt.Sprint(key)] = normalize(element)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, element := range v {
+			result[i] = normalize(element)
+		}
+		return result
+	}
+	return value
+}
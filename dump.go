@@ -0,0 +1,213 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gomatbase/go-error"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ErrUnknownFormat = err.ErrorF("Unknown configuration format %s")
+)
+
+const (
+	TextFormat = "text"
+	JsonFormat = "json"
+	YamlFormat = "yaml"
+
+	// DefaultPrintConfigSwitch is the default cml switch requesting the configuration to be printed
+	DefaultPrintConfigSwitch = "print-config"
+
+	// masked value of secret variables
+	redacted = "******"
+)
+
+// ConfigurationEntry
+// Effective configuration of a variable.
+type ConfigurationEntry struct {
+	Name      string      `json:"name" yaml:"name"`
+	Value     interface{} `json:"value" yaml:"value"`
+	Source    string      `json:"source,omitempty" yaml:"source,omitempty"`
+	Required  bool        `json:"required" yaml:"required"`
+	Defaulted bool        `json:"defaulted" yaml:"defaulted"`
+	Secret    bool        `json:"secret" yaml:"secret"`
+}
+
+// Configuration
+// Effective configuration of an environment. Variables holds the registered variables and AdHoc the variables
+// which have been looked up without being registered. Both are sorted by name.
+type Configuration struct {
+	Variables []ConfigurationEntry `json:"variables" yaml:"variables"`
+	AdHoc     []ConfigurationEntry `json:"adHoc" yaml:"adHoc"`
+}
+
+// Configuration
+// Gets the effective configuration of the environment. Values of secret variables are masked.
+func (en *Environment) Configuration() Configuration {
+	en.lock.Lock()
+	var adHoc []string
+	for name := range en.adHoc {
+		if _, isVariable := en.variables[name]; !isVariable {
+			adHoc = append(adHoc, name)
+		}
+	}
+	en.lock.Unlock()
+
+	configuration := Configuration{
+		Variables: []ConfigurationEntry{},
+		AdHoc:     []ConfigurationEntry{},
+	}
+	for _, v := range en.variablesSnapshot() {
+		value, s := en.lookup(v.name)
+		entry := ConfigurationEntry{
+			Name:      v.name,
+			Value:     normalize(value),
+			Required:  v.required,
+			Defaulted: value != nil && s == nil,
+			Secret:    v.isSecret(),
+		}
+		if value != nil {
+			entry.Source = sourceName(s)
+			if v.isSecret() {
+				entry.Value = redacted
+			}
+		}
+		configuration.Variables = append(configuration.Variables, entry)
+	}
+	for _, name := range adHoc {
+		value, s := en.lookup(name)
+		entry := ConfigurationEntry{
+			Name:  name,
+			Value: normalize(value),
+		}
+		if value != nil {
+			entry.Source = sourceName(s)
+		}
+		if text, isString := entry.Value.(string); isString {
+			// ad-hoc values may interpolate values of secret variables
+			entry.Value = en.redact(text)
+		}
+		configuration.AdHoc = append(configuration.AdHoc, entry)
+	}
+
+	sort.Slice(configuration.Variables, func(i, j int) bool {
+		return configuration.Variables[i].Name < configuration.Variables[j].Name
+	})
+	sort.Slice(configuration.AdHoc, func(i, j int) bool {
+		return configuration.AdHoc[i].Name < configuration.AdHoc[j].Name
+	})
+	return configuration
+}
+
+// PrintConfig
+// Writes the effective configuration of the environment in the given format (text, json or yaml).
+func (en *Environment) PrintConfig(w io.Writer, format string) error {
+	configuration := en.Configuration()
+	switch strings.ToLower(format) {
+	case TextFormat, "":
+		return configuration.writeText(w)
+	case JsonFormat:
+		b, e := json.MarshalIndent(configuration, "", "  ")
+		if e != nil {
+			return e
+		}
+		_, e = fmt.Fprintln(w, string(b))
+		return e
+	case YamlFormat:
+		b, e := yaml.Marshal(configuration)
+		if e != nil {
+			return e
+		}
+		_, e = w.Write(b)
+		return e
+	}
+	return ErrUnknownFormat.WithValues(format)
+}
+
+// PrintConfigIfRequested
+// Writes the effective configuration of the environment if the print config switch (see Settings) is set in the
+// command line. The switch value is the format, text if none is given. Returns true if the configuration was
+// requested, so the application may terminate.
+func (en *Environment) PrintConfigIfRequested(w io.Writer) (bool, error) {
+	format := en.cmlProvider.Get(en.settings.PrintConfigSwitch, nil)
+	if format == nil {
+		return false, nil
+	}
+	return true, en.PrintConfig(w, format.(string))
+}
+
+func (c Configuration) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "VARIABLE\tVALUE\tSOURCE\tFLAGS")
+	for _, entry := range c.Variables {
+		var flags []string
+		if entry.Required {
+			flags = append(flags, "required")
+		}
+		if entry.Defaulted {
+			flags = append(flags, "defaulted")
+		}
+		if entry.Secret {
+			flags = append(flags, "secret")
+		}
+		value := textValue(entry.Value)
+		if entry.Secret && entry.Value != nil {
+			value = redacted
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", entry.Name, value, textSource(entry.Source), strings.Join(flags, ","))
+	}
+	if len(c.AdHoc) > 0 {
+		_, _ = fmt.Fprintln(tw)
+		_, _ = fmt.Fprintln(tw, "AD-HOC VARIABLE\tVALUE\tSOURCE\t")
+		for _, entry := range c.AdHoc {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t\n", entry.Name, textValue(entry.Value), textSource(entry.Source))
+		}
+	}
+	return tw.Flush()
+}
+
+func textValue(value interface{}) string {
+	if value == nil {
+		return "-"
+	}
+	if s, isString := value.(string); isString {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(value)
+}
+
+func textSource(source string) string {
+	if source == "" {
+		return "-"
+	}
+	return source
+}
+
+// GetConfiguration
+// Gets the effective configuration of the default environment (see Environment.Configuration).
+func GetConfiguration() Configuration {
+	return env.Configuration()
+}
+
+// PrintConfig
+// Writes the effective configuration of the default environment (see Environment.PrintConfig).
+func PrintConfig(w io.Writer, format string) error {
+	return env.PrintConfig(w, format)
+}
+
+// PrintConfigIfRequested
+// Writes the effective configuration of the default environment if requested (see
+// Environment.PrintConfigIfRequested).
+func PrintConfigIfRequested(w io.Writer) (bool, error) {
+	return env.PrintConfigIfRequested(w)
+}
@@ -6,13 +6,18 @@ package env
 
 import (
 	"log"
+	"strings"
 	"sync"
 
 	"github.com/gomatbase/go-error"
 )
 
 const (
-	ErrVariableAlreadyExists = err.Error("Variable name already exists.")
+	ErrVariableAlreadyExists  = err.Error("Variable name already exists.")
+	ErrConverterFailure       = err.ErrorF("Unable to convert variable %s (from %s): %v")
+	ErrProviderLoadFailure    = err.ErrorF("Unable to load provider %s: %v")
+	ErrProviderRefreshFailure = err.ErrorF("Unable to refresh provider %s: %v")
+	ErrCyclicDependency       = err.ErrorF("Cyclic dependency between providers %s")
 )
 
 type providerRegistry struct {
@@ -21,26 +26,35 @@ type providerRegistry struct {
 	lock      sync.Mutex
 }
 
-var env = &struct {
+// Environment
+// Set of variables with its own providers and settings. Package level functions operate on a default environment
+// using the built-in providers.
+type Environment struct {
 	variables map[string]*variable
+	adHoc     map[string]bool
 	providers map[Provider]*providerRegistry
+	order     []Provider
+	watchers  []*Watcher
+	rules     []func(environment *Environment) []error
 	settings  Settings
-}{
-	variables: make(map[string]*variable),
-	providers: map[Provider]*providerRegistry{
-		CmlArgumentsProvider():         newProviderRegistry(),
-		JsonConfigurationProvider():    newProviderRegistry(),
-		YamlConfigurationProvider():    newProviderRegistry(),
-		EnvironmentVariablesProvider(): newProviderRegistry(),
-	},
-	settings: Settings{
-		DefaultSources: []Source{
-			CmlArgumentsSource(),
-			JsonConfigurationSource(),
-			YamlConfigurationSource(),
-			EnvironmentVariablesSource(),
-		},
-	},
+	lock      sync.Mutex
+
+	// built-in providers of the environment
+	cmlProvider    *cmlArgumentsProvider
+	envProvider    *environmentVariablesProvider
+	jsonProvider   *jsonConfigurationProvider
+	yamlProvider   *yamlConfigurationProvider
+	dotEnvProvider *dotEnvConfigurationProvider
+
+	// profiles holds where the active profiles are given, shared with the built-in configuration providers
+	profiles *profileSelector
+}
+
+// env is the default environment, created on initialization as its providers refer to it when created on their own
+var env *Environment
+
+func init() {
+	env = New(Settings{})
 }
 
 func newProviderRegistry() *providerRegistry {
@@ -51,40 +65,134 @@ func newProviderRegistry() *providerRegistry {
 	}
 }
 
-var lock = sync.Mutex{}
-
 type Settings struct {
 	FailOnMissingRequired bool
 	DefaultSources        []Source
+	// PrintConfigSwitch is the cml switch requesting the configuration to be printed (see PrintConfigIfRequested).
+	// Defaults to DefaultPrintConfigSwitch.
+	PrintConfigSwitch string
+	// ParallelLoading loads concurrently providers not depending on each other
+	ParallelLoading bool
+	// JsonOptions, YamlOptions and DotEnvOptions configure the built-in file providers of the environment, which use
+	// the default options if not set
+	JsonOptions   *JsonConfigurationProviderOptions
+	YamlOptions   *YamlConfigurationProviderOptions
+	DotEnvOptions *DotEnvConfigurationProviderOptions
+	// ProfileOptions sets where the active profiles are given, the -profile switch and the APP_PROFILE environment
+	// variable if not set
+	ProfileOptions *ProfileOptions
 }
 
-func addVar(v *variable) error {
-	lock.Lock()
+// New
+// Creates a new environment with the given settings. The environment has its own instances of the built-in providers,
+// independent of the other environments ones. If no default sources are given, the environment uses the default chain
+// of its built-in providers: cml arguments, json configuration, yaml configuration and environment variables. The
+// providers of the default sources are registered in the environment.
+func New(settings Settings) *Environment {
+	en := &Environment{
+		variables:   make(map[string]*variable),
+		adHoc:       make(map[string]bool),
+		providers:   make(map[Provider]*providerRegistry),
+		cmlProvider: &cmlArgumentsProvider{},
+		envProvider: &environmentVariablesProvider{},
+		profiles:    &profileSelector{options: defaultProfileOptions},
+	}
+	if settings.ProfileOptions != nil {
+		en.profiles.options = *settings.ProfileOptions
+	}
+
+	jsonOptions := defaultJsonConfigurationProviderOptions
+	if settings.JsonOptions != nil {
+		jsonOptions = *settings.JsonOptions
+	}
+	yamlOptions := defaultYamlConfigurationProviderOptions
+	if settings.YamlOptions != nil {
+		yamlOptions = *settings.YamlOptions
+	}
+	dotEnvOptions := defaultDotEnvConfigurationProviderOptions
+	if settings.DotEnvOptions != nil {
+		dotEnvOptions = *settings.DotEnvOptions
+	}
+	en.jsonProvider = newJsonConfigurationProvider(jsonOptions, en.cmlProvider, en.envProvider, en.profiles)
+	en.yamlProvider = newYamlConfigurationProvider(yamlOptions, en.cmlProvider, en.envProvider, en.profiles)
+	en.dotEnvProvider = newDotEnvConfigurationProvider(dotEnvOptions, en.cmlProvider)
+
+	if len(settings.DefaultSources) == 0 {
+		settings.DefaultSources = []Source{
+			en.CmlArgumentsSource(),
+			en.JsonConfigurationSource(),
+			en.YamlConfigurationSource(),
+			en.EnvironmentVariablesSource(),
+		}
+	} else {
+		settings.DefaultSources = append([]Source{}, settings.DefaultSources...)
+	}
+	if settings.PrintConfigSwitch == "" {
+		settings.PrintConfigSwitch = DefaultPrintConfigSwitch
+	}
+	en.settings = settings
+
+	for _, s := range settings.DefaultSources {
+		en.register(s.Provider())
+	}
+	return en
+}
 
-	if _, found := env.variables[v.name]; found {
-		lock.Unlock()
+// register registers a provider in the environment, if not yet registered. The environment lock must be held.
+func (en *Environment) register(p Provider) *providerRegistry {
+	registry, found := en.providers[p]
+	if !found {
+		registry = newProviderRegistry()
+		en.providers[p] = registry
+		en.order = append(en.order, p)
+	}
+	return registry
+}
+
+// isDirty checks if a provider has been updated by the ongoing refresh
+func (en *Environment) isDirty(p Provider) bool {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	registry, found := en.providers[p]
+	return found && registry.dirty
+}
+
+// Default
+// Gets the default environment used by the package level functions.
+func Default() *Environment {
+	return env
+}
+
+// Var
+// Creates a variable to be added to the environment.
+func (en *Environment) Var(name string) *variable {
+	return &variable{name: name, sources: make([]*source, 0), environment: en}
+}
+
+func (en *Environment) addVar(v *variable) error {
+	en.lock.Lock()
+
+	if _, found := en.variables[v.name]; found {
+		en.lock.Unlock()
 		return ErrVariableAlreadyExists
 	}
 
-	env.variables[v.name] = v
-	lock.Unlock()
+	en.variables[v.name] = v
+	en.lock.Unlock()
 
 	// now let's check each of the providers, register unknown providers, and register the variable with its providers
-	if len(v.sources) == 0 {
+	if len(v.sources) == 0 && v.compute == nil {
 		// no specific sources provided, let's give it the default ones
-		v.sources = make([]*source, len(env.settings.DefaultSources))
-		for i, s := range env.settings.DefaultSources {
+		defaultSources := en.defaultSources(v.scoped)
+		v.sources = make([]*source, len(defaultSources))
+		for i, s := range defaultSources {
 			v.sources[i] = &source{source: s}
 		}
 	} else {
 		for _, s := range v.sources {
-			lock.Lock()
-			registry, found := env.providers[s.source.Provider()]
-			if !found {
-				registry = newProviderRegistry()
-				env.providers[s.source.Provider()] = registry
-			}
-			lock.Unlock()
+			en.lock.Lock()
+			registry := en.register(s.source.Provider())
+			en.lock.Unlock()
 			registry.lock.Lock()
 			registry.variables = append(registry.variables, v)
 			registry.lock.Unlock()
@@ -94,34 +202,141 @@ func addVar(v *variable) error {
 	return nil
 }
 
+// variablesSnapshot gets the variables currently added to the environment
+func (en *Environment) variablesSnapshot() []*variable {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	variables := make([]*variable, 0, len(en.variables))
+	for _, v := range en.variables {
+		variables = append(variables, v)
+	}
+	return variables
+}
+
 // Load
-// initializes environment with provided configuration
-func Load() []error {
+// initializes environment with provided configuration. Providers are loaded after the providers they depend on (see
+// DependentProvider), otherwise in registration order. Independent providers are loaded concurrently if the
+// environment settings allow it. Failures are returned annotated with the name of the failing provider.
+func (en *Environment) Load() []error {
+	levels, e := en.loadingLevels()
 	var result []error
-	for provider := range env.providers {
-		if e := provider.Load(); e != nil {
-			result = append(result, e)
+	if e != nil {
+		result = append(result, e)
+	}
+	for _, level := range levels {
+		errors := make([]error, len(level))
+		if en.settings.ParallelLoading && len(level) > 1 {
+			wg := sync.WaitGroup{}
+			for i, provider := range level {
+				wg.Add(1)
+				go func(i int, provider Provider) {
+					defer wg.Done()
+					errors[i] = provider.Load()
+				}(i, provider)
+			}
+			wg.Wait()
+		} else {
+			for i, provider := range level {
+				errors[i] = provider.Load()
+			}
+		}
+		for i, e := range errors {
+			if e != nil {
+				result = append(result, ErrProviderLoadFailure.WithValues(describeProvider(level[i]), e))
+			}
 		}
 	}
 
 	return result
 }
 
-func FailOnMissingVariables(flag bool) {
-	env.settings.FailOnMissingRequired = flag
+// loadingLevels sorts the registered providers in levels of providers which may be loaded once the previous levels
+// are, in registration order. Dependencies on providers not registered are ignored. Providers with cyclic dependencies
+// are loaded last, one by one, and reported.
+func (en *Environment) loadingLevels() ([][]Provider, error) {
+	providers := en.Providers()
+	registered := make(map[Provider]bool)
+	for _, provider := range providers {
+		registered[provider] = true
+	}
+
+	loaded := make(map[Provider]bool)
+	var levels [][]Provider
+	for len(loaded) < len(providers) {
+		var level []Provider
+		for _, provider := range providers {
+			if !loaded[provider] && dependenciesLoaded(provider, registered, loaded) {
+				level = append(level, provider)
+			}
+		}
+		if len(level) == 0 {
+			var names []string
+			for _, provider := range providers {
+				if !loaded[provider] {
+					levels = append(levels, []Provider{provider})
+					names = append(names, providerName(provider))
+				}
+			}
+			return levels, ErrCyclicDependency.WithValues(strings.Join(names, ", "))
+		}
+		for _, provider := range level {
+			loaded[provider] = true
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+func dependenciesLoaded(p Provider, registered map[Provider]bool, loaded map[Provider]bool) bool {
+	if dependent, isDependent := p.(DependentProvider); isDependent {
+		for _, dependency := range dependent.DependsOn() {
+			if registered[dependency] && !loaded[dependency] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (en *Environment) FailOnMissingVariables(flag bool) {
+	en.settings.FailOnMissingRequired = flag
 }
 
 // Validate
-// validates if all non-string properties have been provided by a suitable format
-func Validate() error {
+// validates if all required variables have been provided, their values could be converted and are accepted by the
+// variables validators, and checks the environment validation rules. All failures are returned aggregated.
+func (en *Environment) Validate() error {
 	errors := err.Errors()
-	for name, variable := range env.variables {
-		if Get(name) == nil && variable.required {
-			errors.AddError(err.Error("Property " + name + " not provided!"))
+	for _, variable := range en.variablesSnapshot() {
+		value, s := en.lookup(variable.name)
+		if value == nil && variable.required {
+			errors.AddError(err.Error("Property " + variable.name + " not provided!"))
+		} else if value == nil {
+			if e := variable.unmet(en); e != nil {
+				errors.AddError(e)
+			}
+		}
+		variable.mutex.Lock()
+		if variable.conversionError != nil {
+			errors.AddError(variable.conversionError)
+		}
+		variable.mutex.Unlock()
+		if value != nil {
+			for _, e := range variable.validate(value, s) {
+				errors.AddError(e)
+			}
+		}
+	}
+	en.lock.Lock()
+	rules := en.rules
+	en.lock.Unlock()
+	for _, rule := range rules {
+		for _, e := range rule(en) {
+			errors.AddError(e)
 		}
 	}
 	if errors.Count() > 0 {
-		if env.settings.FailOnMissingRequired {
+		if en.settings.FailOnMissingRequired {
 			panic(errors)
 		}
 		return errors
@@ -130,134 +345,187 @@ func Validate() error {
 }
 
 // Get
-// Gets the value of a variable if it's provided. Returns nil if not.
-func Get(name string) interface{} {
-	lock.Lock()
-	var v, found = env.variables[name]
-	lock.Unlock()
+// Gets the value of a variable if it's provided. Returns nil if not. Values of secret variables are wrapped in a
+// SecretValue.
+func (en *Environment) Get(name string) interface{} {
+	return en.get(name, false)
+}
+
+// get gets the exposed value of a variable, resolved with the names mapped by a scope if scoped
+func (en *Environment) get(name string, scoped bool) interface{} {
+	value, _, _ := en.resolve(name, nil, scoped)
+	en.lock.Lock()
+	v, found := en.variables[name]
+	en.lock.Unlock()
+	if found {
+		return v.expose(value)
+	}
+	return value
+}
+
+// lookup
+// Gets the value of a variable together with the source that provided it. The source is nil if the value was not
+// provided by any source (default values included).
+func (en *Environment) lookup(name string) (interface{}, Source) {
+	value, s, _ := en.resolve(name, nil, false)
+	return value, s
+}
+
+// resolve gets the value of a variable, its source and the failure processing it. resolving holds the names being
+// resolved, to detect cyclic references. Ad-hoc values failing interpolation are given as provided. Variables read
+// through a scope are resolved with their names mapped (see Scope), without caching the value if the sources of the
+// variable don't map them already.
+func (en *Environment) resolve(name string, resolving []string, scoped bool) (interface{}, Source, error) {
+	en.lock.Lock()
+	var v, found = en.variables[name]
+	en.lock.Unlock()
+
+	if found && scoped {
+		if sources := v.scopedSources(); sources != nil {
+			return en.resolveFrom(v, sources, resolving)
+		}
+	}
 
 	if found {
 		v.mutex.Lock()
 		if v.cachedValue != nil {
 			defer v.mutex.Unlock()
-			return v.cachedValue.value
+			return v.cachedValue.value, v.cachedValue.source, v.conversionError
 		}
 		v.mutex.Unlock()
 	}
 
-	var value interface{}
+	resolving = append(append([]string{}, resolving...), name)
 	if !found {
 		// it's for an ad-hoc value, let's go through the default chain
-		for _, source := range env.settings.DefaultSources {
-			value = source.Provider().Get(name, source.Config())
-			if value != nil {
-				break
-			}
-		}
-	} else {
-		// it's a variable
-		for _, s := range v.sources {
-			sourceValue := s.source.Provider().Get(name, s.source.Config())
-			s.cachedValue = &valuePlaceholder{value: sourceValue} // cache the given value to identify if there were changes in a refresh
-			if value == nil && sourceValue != nil {
-				value = sourceValue
-				if v.converter != nil {
-					value = v.converter(value)
+		en.lock.Lock()
+		en.adHoc[name] = true
+		en.lock.Unlock()
+		for _, source := range en.defaultSources(scoped) {
+			if value := source.Provider().Get(name, source.Config()); value != nil {
+				expanded, _, e := en.interpolate(value, resolving)
+				if e != nil {
+					return value, source, e
 				}
+				return expanded, source, nil
 			}
 		}
-		if value == nil {
-			value = v.defaultValue
+		return nil, nil, nil
+	}
+
+	// it's a variable. Values are processed unlocked as they may reference other variables
+	var value interface{}
+	var valueSource Source
+	sourceValues := make([]interface{}, len(v.sources))
+	for i, s := range v.sources {
+		sourceValues[i] = s.source.Provider().Get(name, s.source.Config())
+		if value == nil && sourceValues[i] != nil {
+			value = sourceValues[i]
+			valueSource = s.source
 		}
-		v.cachedValue = &valuePlaceholder{value: value}
 	}
+	value, valueSource, references, e := en.process(v, value, valueSource, resolving)
+	v.referenceSecrets(en.referencesSecrets(references))
 
-	return value
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	for i, s := range v.sources {
+		s.cachedValue = &valuePlaceholder{value: sourceValues[i]} // cache the given value to identify if there were changes in a refresh
+	}
+	v.conversionError = e
+	v.references = references
+	v.cachedValue = &valuePlaceholder{value: value, source: valueSource}
+	return value, valueSource, e
 }
 
 // Refresh
-// Refreshes asynchronously. Refresh errors are logged
-func Refresh() {
+// Refreshes asynchronously. Refresh errors are logged, with values of secret variables masked
+func (en *Environment) Refresh() {
 	go func() {
-		if e := SyncedRefresh(); e != nil {
-			log.Println(e.Error())
+		if e := en.SyncedRefresh(); e != nil {
+			log.Println(en.redact(e.Error()))
 		}
 	}()
 }
 
 // SyncedRefresh
 // Refreshes Provider configurations synchronously. A provider does not need to guarantee a
-// refresh, but should have an error-free implementation then.
-func SyncedRefresh() error {
+// refresh, but should have an error-free implementation then. Variables referencing changed values are interpolated
+// again, and listeners are only notified once all variables are up-to-date.
+func (en *Environment) SyncedRefresh() error {
 	errors := err.Errors()
-	lock.Lock()
-	for provider, registry := range env.providers {
+	refreshed := false
+	en.lock.Lock()
+	for _, provider := range en.order {
 		if updated, e := provider.Refresh(); e != nil {
-			errors.AddError(e)
+			errors.AddError(ErrProviderRefreshFailure.WithValues(describeProvider(provider), e))
 		} else if updated {
-			registry.dirty = true
+			en.providers[provider].dirty = true
+			refreshed = true
 		}
 	}
-	lock.Unlock()
+	en.lock.Unlock()
 
 	// GOM: needs to be improved... this is a brute-force approach which is ok for now.
 
-	for _, v := range env.variables {
-		v.mutex.Lock()
-		if v.cachedValue == nil {
-			// it was never retrieved, let's initialize the cached value prioritizing the first dirty provider
-			v.cachedValue = &valuePlaceholder{}
-			var dirtyValue interface{}
-			for _, s := range v.sources {
-				isDirtyProvider := env.providers[s.source.Provider()].dirty
-				sourceValue := s.source.Provider().Get(v.name, s.source.Config())
-				s.cachedValue = &valuePlaceholder{value: sourceValue}
-				if sourceValue != nil && (v.cachedValue.value == nil || isDirtyProvider && dirtyValue == nil) {
-					v.cachedValue.value = sourceValue
-					if v.converter != nil {
-						v.cachedValue.value = v.converter(v.cachedValue.value)
-					}
-					if isDirtyProvider && dirtyValue == nil {
-						dirtyValue = sourceValue
-					}
-				}
+	variables := en.variablesSnapshot()
+	changes := make(map[*variable]interface{})
+	failures := make(map[*variable]error)
+	changed := make(map[string]bool)
+	for _, v := range variables {
+		if value, s, updated := en.refreshSources(v); updated {
+			en.update(v, value, s, changes, failures, changed)
+		}
+	}
+
+	// variables referencing changed values are processed again until no more values change
+	pending := changed
+	for pass := 0; pass <= len(variables) && (len(pending) > 0 || refreshed); pass++ {
+		next := make(map[string]bool)
+		for _, v := range variables {
+			if en.dependsOn(v, pending, refreshed) {
+				value, s := v.current()
+				en.update(v, value, s, changes, failures, next)
 			}
+		}
+		for name := range next {
+			changed[name] = true
+		}
+		pending = next
+		refreshed = false
+	}
+
+	for _, v := range variables {
+		if e, failed := failures[v]; failed {
+			errors.AddError(e)
+		}
+		if oldValue, isChanged := changes[v]; isChanged && v.listener != nil {
+			v.mutex.Lock()
+			newValue := v.cachedValue.value
 			v.mutex.Unlock()
-			if v.cachedValue.value != nil && v.listener != nil {
-				v.listener(nil, v.cachedValue)
-			}
-		} else {
-			var newValue interface{}
-			for _, s := range v.sources {
-				if env.providers[s.source.Provider()].dirty {
-					sourceValue := s.source.Provider().Get(v.name, s.source.Config())
-					if sourceValue != s.cachedValue.value {
-						s.cachedValue.value = sourceValue
-						if newValue == nil {
-							newValue = sourceValue
-						}
-					}
-				}
-			}
-			oldValue := v.cachedValue.value
-			if newValue != nil && newValue != v.cachedValue.value {
-				if v.converter != nil {
-					newValue = v.converter(newValue)
-				}
-				v.cachedValue.value = newValue
+			if !equal(oldValue, newValue) {
+				v.listener(v.expose(oldValue), v.expose(newValue))
 			}
-			v.mutex.Unlock()
-			if newValue != nil && oldValue != newValue && v.listener != nil {
-				v.listener(oldValue, newValue)
+		}
+	}
+
+	// watchers are refreshed once all variables are up-to-date, so they get a consistent set of values
+	en.lock.Lock()
+	watchers := en.watchers
+	en.lock.Unlock()
+	for _, w := range watchers {
+		if w.watches(changed) {
+			if e := w.refresh(); e != nil {
+				errors.AddError(e)
 			}
 		}
 	}
 
-	lock.Lock()
-	for _, registry := range env.providers {
+	en.lock.Lock()
+	for _, registry := range en.providers {
 		registry.dirty = false
 	}
-	lock.Unlock()
+	en.lock.Unlock()
 
 	if errors.Count() > 0 {
 		return errors
@@ -265,3 +533,120 @@ func SyncedRefresh() error {
 
 	return nil
 }
+
+// refreshSources gets the values given by the sources of a variable after a refresh, returning the value to be set,
+// if any. A variable which was never retrieved is initialized prioritizing the first dirty provider, otherwise it's
+// resolved again in the order of its sources if the value of any source changed, nil if no source gives a value.
+func (en *Environment) refreshSources(v *variable) (interface{}, Source, bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	var value interface{}
+	var valueSource Source
+	if v.cachedValue == nil {
+		dirtyValue := false
+		for _, s := range v.sources {
+			isDirtyProvider := en.isDirty(s.source.Provider())
+			sourceValue := s.source.Provider().Get(v.name, s.source.Config())
+			s.cachedValue = &valuePlaceholder{value: sourceValue}
+			if sourceValue != nil && (value == nil || isDirtyProvider && !dirtyValue) {
+				value = sourceValue
+				valueSource = s.source
+				dirtyValue = dirtyValue || isDirtyProvider
+			}
+		}
+		return value, valueSource, true
+	}
+
+	updated := false
+	for _, s := range v.sources {
+		if en.isDirty(s.source.Provider()) {
+			sourceValue := s.source.Provider().Get(v.name, s.source.Config())
+			if !equal(sourceValue, s.cachedValue.value) {
+				s.cachedValue.value = sourceValue
+				updated = true
+			}
+		}
+	}
+	if !updated {
+		return nil, nil, false
+	}
+	for _, s := range v.sources {
+		if s.cachedValue.value != nil {
+			return s.cachedValue.value, s.source, true
+		}
+	}
+	return nil, nil, true
+}
+
+// update processes a value given by a source of a variable and sets it, recording the value the variable had before
+// the refresh if it changed. A value failing processing is reported, and the previous value is kept if the variable
+// already had one.
+func (en *Environment) update(v *variable, value interface{}, s Source, changes map[*variable]interface{}, failures map[*variable]error, changed map[string]bool) {
+	v.mutex.Lock()
+	initialized := v.cachedValue != nil
+	var oldValue interface{}
+	if initialized {
+		oldValue = v.cachedValue.value
+	}
+	v.mutex.Unlock()
+
+	newValue, newSource, references, e := en.process(v, value, s, []string{v.name})
+	secretReferences := en.referencesSecrets(references)
+	v.mutex.Lock()
+	v.conversionError = e
+	if e == nil || !initialized {
+		v.referenceSecrets(secretReferences)
+		v.references = references
+		v.cachedValue = &valuePlaceholder{value: newValue, source: newSource}
+	}
+	v.mutex.Unlock()
+
+	delete(failures, v)
+	if e != nil {
+		failures[v] = e
+		if initialized {
+			return
+		}
+	}
+	if !equal(oldValue, newValue) {
+		if _, recorded := changes[v]; !recorded {
+			changes[v] = oldValue
+		}
+		changed[v.name] = true
+	}
+}
+
+// Load
+// initializes the default environment with provided configuration
+func Load() []error {
+	return env.Load()
+}
+
+func FailOnMissingVariables(flag bool) {
+	env.FailOnMissingVariables(flag)
+}
+
+// Validate
+// validates the default environment (see Environment.Validate)
+func Validate() error {
+	return env.Validate()
+}
+
+// Get
+// Gets the value of a variable of the default environment if it's provided. Returns nil if not.
+func Get(name string) interface{} {
+	return env.Get(name)
+}
+
+// Refresh
+// Refreshes the default environment asynchronously. Refresh errors are logged
+func Refresh() {
+	env.Refresh()
+}
+
+// SyncedRefresh
+// Refreshes the default environment synchronously (see Environment.SyncedRefresh).
+func SyncedRefresh() error {
+	return env.SyncedRefresh()
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// locator is implemented by providers able to describe where they look for a variable (file and property, switch,
+// environment variable name...)
+type locator interface {
+	locate(name string, config interface{}) string
+}
+
+// Explanation
+// Describes how the value of a variable was resolved.
+type Explanation struct {
+	// Name of the variable
+	Name string
+	// Registered is false for ad-hoc variables, resolved through the default chain of sources
+	Registered bool
+	// Value is the resolved value of the variable
+	Value interface{}
+	// Sources of the variable, in priority order
+	Sources []SourceExplanation
+	// DefaultApplied is true if the value is the variable default value
+	DefaultApplied bool
+	// ConverterApplied is true if the value was converted by the variable converter
+	ConverterApplied bool
+	// ConversionError is the failure interpolating or converting the value, if it failed
+	ConversionError error
+}
+
+// SourceExplanation
+// Describes the value given by one of the sources of a variable.
+type SourceExplanation struct {
+	// Provider is the name of the source provider
+	Provider string
+	// Location describes where the provider looks for the variable
+	Location string
+	// Value returned by the source, nil if it didn't provide one
+	Value interface{}
+	// Winner is true for the source providing the variable value
+	Winner bool
+	// Description of the source provider (see NamedProvider)
+	Description string
+}
+
+// Explain
+// Explains how the value of a variable is resolved, listing every source of the variable in priority order and the
+// value given by each one of them. Registered variables are explained with the values they currently hold. Values of
+// secret variables are wrapped in a SecretValue.
+func (en *Environment) Explain(name string) Explanation {
+	return en.explain(name, false)
+}
+
+// explain explains how the value of a variable is resolved, with the names mapped by a scope if scoped. Variables
+// whose value is not cached for the mapped names are explained as they are resolved, like ad-hoc variables.
+func (en *Environment) explain(name string, scoped bool) Explanation {
+	en.lock.Lock()
+	v, found := en.variables[name]
+	en.lock.Unlock()
+
+	var sources []Source
+	if !found {
+		sources = en.defaultSources(scoped)
+	} else if scoped {
+		sources = v.scopedSources()
+	}
+
+	explanation := Explanation{Name: name, Registered: found}
+	if sources != nil {
+		// the value is resolved as Get does, interpolated, sources listing the values they provide as they are
+		value, source, e := en.resolve(name, nil, scoped)
+		explanation.Value = value
+		if found {
+			explanation.Value = v.expose(value)
+			explanation.DefaultApplied = source == nil && value != nil
+			explanation.ConverterApplied = v.converter != nil && source != nil
+		} else if text, isString := value.(string); isString {
+			// ad-hoc values may interpolate values of secret variables
+			explanation.Value = en.redact(text)
+		}
+		explanation.ConversionError = e
+		for _, s := range sources {
+			sourceValue := s.Provider().Get(name, s.Config())
+			if found {
+				sourceValue = v.expose(sourceValue)
+			}
+			winner := value != nil && source != nil && s.Provider() == source.Provider()
+			explanation.Sources = append(explanation.Sources, explainSource(name, s, sourceValue, winner))
+		}
+		return explanation
+	}
+
+	en.lookup(name) // makes sure the variable has been resolved
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	explanation.Value = v.expose(v.cachedValue.value)
+	for _, s := range v.sources {
+		var value interface{}
+		if s.cachedValue != nil {
+			value = v.expose(s.cachedValue.value)
+		}
+		winner := v.cachedValue.source == s.source
+		explanation.Sources = append(explanation.Sources, explainSource(name, s.source, value, winner))
+	}
+	explanation.DefaultApplied = v.cachedValue.source == nil && v.cachedValue.value != nil
+	explanation.ConverterApplied = v.converter != nil && v.cachedValue.source != nil
+	explanation.ConversionError = v.conversionError
+	return explanation
+}
+
+func explainSource(name string, s Source, value interface{}, winner bool) SourceExplanation {
+	explanation := SourceExplanation{
+		Provider: sourceName(s),
+		Value:    value,
+		Winner:   winner,
+	}
+	if named, isNamed := s.Provider().(NamedProvider); isNamed {
+		explanation.Description = named.Description()
+	}
+	if l, isLocator := s.Provider().(locator); isLocator {
+		explanation.Location = l.locate(name, s.Config())
+	}
+	return explanation
+}
+
+// String
+// Describes the explanation in human-readable form, a line per source.
+func (e Explanation) String() string {
+	buffer := &bytes.Buffer{}
+	kind := "variable"
+	if !e.Registered {
+		kind = "ad-hoc variable"
+	}
+	_, _ = fmt.Fprintf(buffer, "%s %s = %v\n", kind, e.Name, e.Value)
+	for i, s := range e.Sources {
+		marker := " "
+		if s.Winner {
+			marker = "*"
+		}
+		_, _ = fmt.Fprintf(buffer, "%s %d. %s: %s = %v\n", marker, i+1, s.Provider, s.Location, s.Value)
+	}
+	if e.DefaultApplied {
+		_, _ = fmt.Fprintln(buffer, "  default value applied")
+	}
+	if e.ConverterApplied {
+		_, _ = fmt.Fprintln(buffer, "  converter applied")
+	}
+	if e.ConversionError != nil {
+		_, _ = fmt.Fprintln(buffer, "  conversion failed:", e.ConversionError)
+	}
+	return buffer.String()
+}
+
+// Explain
+// Explains how the value of a variable of the default environment is resolved (see Environment.Explain).
+func Explain(name string) Explanation {
+	return env.Explain(name)
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/gomatbase/go-error"
+)
+
+const (
+	ErrInterpolationFailure = err.ErrorF("Unable to interpolate variable %s (from %s): %v")
+	ErrCyclicReference      = err.ErrorF("Cyclic reference %s")
+)
+
+// interpolate expands the ${name} and ${name:-default} placeholders of a string value, resolving the referenced names
+// like Get does. A placeholder is escaped by doubling the dollar sign ($${name} gives ${name}). Values which are not
+// strings are returned untouched. Returns the expanded value and the referenced names.
+func (en *Environment) interpolate(value interface{}, resolving []string) (interface{}, []string, error) {
+	text, isString := value.(string)
+	if !isString || !strings.Contains(text, "${") {
+		return value, nil, nil
+	}
+	var references []string
+	expanded, e := en.expand(text, resolving, &references)
+	if e != nil {
+		return nil, references, e
+	}
+	return expanded, references, nil
+}
+
+// expand replaces the placeholders of a text, collecting the referenced names
+func (en *Environment) expand(text string, resolving []string, references *[]string) (string, error) {
+	buffer := &bytes.Buffer{}
+	for i := 0; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], "$${"):
+			buffer.WriteString("${")
+			i += 2
+		case strings.HasPrefix(text[i:], "${"):
+			end := closingBrace(text, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated placeholder %s", text[i:])
+			}
+			name, defaultValue, hasDefault := text[i+2:end], "", false
+			if separator := strings.Index(name, ":-"); separator >= 0 {
+				name, defaultValue, hasDefault = name[:separator], name[separator+2:], true
+			}
+			name = strings.TrimSpace(name)
+			*references = append(*references, name)
+
+			value, e := en.reference(name, resolving)
+			if e != nil {
+				return "", e
+			}
+			if value == nil {
+				if !hasDefault {
+					return "", fmt.Errorf("unresolved reference %s", name)
+				}
+				if value, e = en.expand(defaultValue, resolving, references); e != nil {
+					return "", e
+				}
+			}
+			s, e := asString(value)
+			if e != nil {
+				return "", fmt.Errorf("reference %s is not a scalar value: %v", name, e)
+			}
+			buffer.WriteString(s.(string))
+			i = end
+		default:
+			buffer.WriteByte(text[i])
+		}
+	}
+	return buffer.String(), nil
+}
+
+// closingBrace finds the brace closing a placeholder starting at the given position, taking nested placeholders into
+// account. Returns -1 if the placeholder is not closed.
+func closingBrace(text string, start int) int {
+	depth := 1
+	for i := start; i < len(text); i++ {
+		if strings.HasPrefix(text[i:], "${") {
+			depth++
+			i++
+		} else if text[i] == '}' {
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// reference resolves a name referenced by a placeholder. Names already being resolved are cyclic references.
+func (en *Environment) reference(name string, resolving []string) (interface{}, error) {
+	for i, resolved := range resolving {
+		if resolved == name {
+			cycle := append(append([]string{}, resolving[i:]...), name)
+			return nil, ErrCyclicReference.WithValues(strings.Join(cycle, " -> "))
+		}
+	}
+	value, _, e := en.resolve(name, resolving, false)
+	if value == nil && e != nil {
+		return nil, e
+	}
+	return value, nil
+}
+
+// process interpolates and converts a value given by a source of a variable (or computes the value of a computed
+// variable), falling back to the variable default value when there's no value or it can't be processed. Returns the
+// value, its source (nil for the default value), the names it references and the processing failure.
+func (en *Environment) process(v *variable, value interface{}, s Source, resolving []string) (interface{}, Source, []string, error) {
+	if v.compute != nil {
+		return en.computeValue(v, resolving)
+	}
+	expanded, references, e := en.interpolate(value, resolving)
+	if e != nil {
+		if v.isSecret() {
+			e = maskError(e, value)
+		}
+		e = ErrInterpolationFailure.WithValues(v.name, sourceName(s), e)
+	} else {
+		expanded, e = v.convert(expanded, s)
+	}
+	if expanded != nil {
+		return expanded, s, references, nil
+	}
+
+	defaultValue, defaultReferences, de := en.interpolate(v.defaults(), resolving)
+	if de != nil && e == nil {
+		e = ErrInterpolationFailure.WithValues(v.name, sourceName(nil), de)
+	}
+	return defaultValue, nil, append(references, defaultReferences...), e
+}
+
+// dependsOn checks if any of the names referenced by the current value of a variable is one of the given names. Names
+// which are not variables of the environment are matched if providers were refreshed, as they may have changed.
+func (en *Environment) dependsOn(v *variable, names map[string]bool, refreshed bool) bool {
+	v.mutex.Lock()
+	references := v.references
+	v.mutex.Unlock()
+	for _, name := range references {
+		if names[name] {
+			return true
+		}
+		if refreshed {
+			en.lock.Lock()
+			_, isVariable := en.variables[name]
+			en.lock.Unlock()
+			if !isVariable {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// KeyLister
+// A Provider able to enumerate the names of the variables it provides.
+type KeyLister interface {
+	Provider
+
+	// Keys gets the names of the variables provided under the given prefix, which is a dot separated path (database
+	// matches database.host but not databases). An empty prefix gets all names.
+	Keys(prefix string) []string
+}
+
+// Keys
+// Gets the names of the variables provided under the given prefix (see KeyLister) by the providers of the default
+// sources, sorted and without duplicates. Providers not implementing KeyLister are ignored.
+func (en *Environment) Keys(prefix string) []string {
+	var keys []string
+	for _, s := range en.DefaultSources() {
+		if lister, isLister := s.Provider().(KeyLister); isLister {
+			keys = append(keys, lister.Keys(prefix)...)
+		}
+	}
+	return filterKeys(keys, prefix)
+}
+
+// Keys
+// Gets the names of the variables provided under the given prefix in the default environment (see Environment.Keys).
+func Keys(prefix string) []string {
+	return env.Keys(prefix)
+}
+
+// hasPrefix checks if a name is under the given dot separated prefix
+func hasPrefix(name string, prefix string) bool {
+	return prefix == "" || name == prefix || strings.HasPrefix(name, prefix+".")
+}
+
+// flatten collects the paths of the values of a configuration object (see Lookup). Objects are walked and any other
+// value is a leaf.
+func flatten(path string, value interface{}, keys []string) []string {
+	join := func(key interface{}) string {
+		if path == "" {
+			return quoteKey(fmt.Sprint(key))
+		}
+		return path + "." + quoteKey(fmt.Sprint(key))
+	}
+	switch object := value.(type) {
+	case map[string]interface{}:
+		for key, child := range object {
+			keys = flatten(join(key), child, keys)
+		}
+	case map[interface{}]interface{}:
+		for key, child := range object {
+			keys = flatten(join(key), child, keys)
+		}
+	default:
+		keys = append(keys, path)
+	}
+	return keys
+}
+
+// filterKeys gets the names under the given prefix, sorted and without duplicates
+func filterKeys(names []string, prefix string) []string {
+	found := make(map[string]bool)
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		if hasPrefix(name, prefix) && !found[name] {
+			found[name] = true
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
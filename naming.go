@@ -0,0 +1,143 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy
+// Gets the names a variable may be given in a provider, in order of preference. Providers use the first name they
+// provide a value for. Names set explicitly in a variable source are used as given, the strategy is not applied.
+type NamingStrategy func(name string) []string
+
+// ExactNaming
+// Looks for variables only by their own name. It's the strategy of providers with no strategy set.
+func ExactNaming(name string) []string {
+	return []string{name}
+}
+
+// KebabNaming
+// Looks for variables by their name and then by its kebab-case form (database.maxConnections gives
+// database.max-connections), as usual for command line switches.
+func KebabNaming(name string) []string {
+	return []string{name, joinWords(name, ".", "-", strings.ToLower)}
+}
+
+// SnakeNaming
+// Looks for variables by their name and then by its snake-case form (database.maxConnections gives
+// database.max_connections).
+func SnakeNaming(name string) []string {
+	return []string{name, joinWords(name, ".", "_", strings.ToLower)}
+}
+
+// RelaxedNaming
+// Looks for variables by their name and then by its kebab-case and snake-case forms, as they may be written in
+// configuration files.
+func RelaxedNaming(name string) []string {
+	return []string{name, joinWords(name, ".", "-", strings.ToLower), joinWords(name, ".", "_", strings.ToLower)}
+}
+
+// EnvironmentNaming
+// Looks for variables by their name and then by its upper snake-case form with dots as underscores
+// (database.maxConnections gives DATABASE_MAX_CONNECTIONS), as usual for environment variables.
+func EnvironmentNaming(name string) []string {
+	return []string{name, joinWords(name, "_", "_", strings.ToUpper)}
+}
+
+// UseRelaxedNaming
+// Sets relaxed naming strategies for the built-in providers of the default environment (see
+// Environment.UseRelaxedNaming).
+func UseRelaxedNaming(environmentPrefix string) {
+	env.UseRelaxedNaming(environmentPrefix)
+}
+
+// UseRelaxedNaming
+// Sets relaxed naming strategies for the built-in providers of the environment: EnvironmentNaming with the given
+// application prefix for environment variables (MYAPP_ finds database.maxConnections in MYAPP_DATABASE_MAX_CONNECTIONS),
+// KebabNaming for command line switches, RelaxedNaming for the json and yaml configurations and EnvironmentNaming for
+// dotenv configurations, which hold environment variables.
+func (en *Environment) UseRelaxedNaming(environmentPrefix string) {
+	en.envProvider.UseNaming(EnvironmentNaming).UsePrefix(environmentPrefix)
+	en.cmlProvider.UseNaming(KebabNaming)
+	en.jsonProvider.UseNaming(RelaxedNaming)
+	en.yamlProvider.UseNaming(RelaxedNaming)
+	en.dotEnvProvider.UseNaming(EnvironmentNaming)
+}
+
+// namesOf gets the distinct names given by a strategy for a variable, the variable name if there's no strategy
+func namesOf(strategy NamingStrategy, name string) []string {
+	if strategy == nil {
+		return []string{name}
+	}
+	var names []string
+	for _, candidate := range strategy(name) {
+		if candidate != "" && !contains(names, candidate) {
+			names = append(names, candidate)
+		}
+	}
+	if len(names) == 0 {
+		return []string{name}
+	}
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// joinWords splits each dot separated segment of a name into words, which are delimited by dashes, underscores or
+// camel case, and joins them back, with the given separators between segments and between words.
+func joinWords(name string, segmentSeparator string, wordSeparator string, transform func(string) string) string {
+	segments := strings.Split(name, ".")
+	for i, segment := range segments {
+		segments[i] = transform(strings.Join(words(segment), wordSeparator))
+	}
+	return strings.Join(segments, segmentSeparator)
+}
+
+// environmentName gets the environment variable name of a property, with dots and list indices as underscores
+// (servers[0].maxConnections gives SERVERS_0_MAX_CONNECTIONS)
+func environmentName(path string) string {
+	segments, e := parsePath(path)
+	if e != nil {
+		return joinWords(path, "_", "_", strings.ToUpper)
+	}
+	names := make([]string, len(segments))
+	for i, segment := range segments {
+		names[i] = strings.ToUpper(strings.Join(words(segment.name()), "_"))
+	}
+	return strings.Join(names, "_")
+}
+
+// words splits a name into words, keeping acronyms together (maxHTTPConnections gives max, HTTP and Connections)
+func words(name string) []string {
+	var result []string
+	runes := []rune(name)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '-' || runes[i] == '_':
+			if i > start {
+				result = append(result, string(runes[start:i]))
+			}
+			start = i + 1
+		case i > start && unicode.IsUpper(runes[i]) &&
+			(!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			result = append(result, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		result = append(result, string(runes[start:]))
+	}
+	return result
+}
@@ -0,0 +1,368 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gomatbase/go-error"
+)
+
+const (
+	ErrInvalidPath    = err.ErrorF("Invalid path %s: %s")
+	ErrPathIntoScalar = err.ErrorF("Path %s walks into the scalar value at %s")
+)
+
+// pathSegment is a step of a path: an object key or a list index, any of them when it's a wildcard
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func (ps pathSegment) String() string {
+	switch {
+	case ps.isIndex && ps.wildcard:
+		return "[*]"
+	case ps.isIndex:
+		return "[" + strconv.Itoa(ps.index) + "]"
+	case ps.wildcard:
+		return "*"
+	}
+	return quoteKey(ps.key)
+}
+
+// name gets the key of an object matched by the segment, which is the index for index segments
+func (ps pathSegment) name() string {
+	if ps.isIndex {
+		return strconv.Itoa(ps.index)
+	}
+	return ps.key
+}
+
+// Lookup
+// Gets the value at the given path of a configuration object. Paths are dot separated keys with the following syntax:
+//
+//	servers[0].host        list elements by index (servers.0.host is also accepted)
+//	servers[*].host        all the elements of a list, giving a list with the matches
+//	section.*              all the values of an object, sorted by key, giving a list with the matches
+//	logging."com.example"  quoted keys, which may contain dots, brackets or escaped quotes (\")
+//	logging["com.example"] quoted keys between brackets
+//	logging.com\.example   escaped characters
+//
+// Returns nil if the path is not found and ErrPathIntoScalar if it walks into a scalar value. Wildcards ignore the
+// values they can't walk into.
+func Lookup(value interface{}, path string) (interface{}, error) {
+	segments, e := parsePath(path)
+	if e != nil {
+		return nil, e
+	}
+	return walk(value, path, segments, 0)
+}
+
+// parsePath splits a path into its segments
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	i := 0
+	expectKey := true
+	for i < len(path) {
+		switch {
+		case path[i] == '[':
+			end := closingBracket(path, i+1)
+			if end < 0 {
+				return nil, ErrInvalidPath.WithValues(path, "unterminated bracket")
+			}
+			content := path[i+1 : end]
+			switch {
+			case content == "*":
+				segments = append(segments, pathSegment{isIndex: true, wildcard: true})
+			case len(content) > 1 && (content[0] == '"' || content[0] == '\'') && content[len(content)-1] == content[0]:
+				key, end, e := unquote(content, 0)
+				if e == nil && end != len(content) {
+					e = fmt.Errorf("unexpected %s after quoted key", content[end:])
+				}
+				if e != nil {
+					return nil, ErrInvalidPath.WithValues(path, e.Error())
+				}
+				segments = append(segments, pathSegment{key: key})
+			default:
+				index, e := strconv.Atoi(content)
+				if e != nil || index < 0 {
+					return nil, ErrInvalidPath.WithValues(path, "invalid index "+content)
+				}
+				segments = append(segments, pathSegment{index: index, isIndex: true})
+			}
+			i = end + 1
+			expectKey = false
+		case path[i] == '.':
+			if expectKey {
+				return nil, ErrInvalidPath.WithValues(path, "empty key")
+			}
+			i++
+			expectKey = true
+			if i == len(path) {
+				return nil, ErrInvalidPath.WithValues(path, "empty key")
+			}
+		default:
+			if !expectKey {
+				return nil, ErrInvalidPath.WithValues(path, "missing separator before "+path[i:])
+			}
+			var key string
+			var quoted bool
+			var e error
+			if path[i] == '"' || path[i] == '\'' {
+				key, i, e = unquote(path, i)
+				quoted = true
+			} else {
+				key, i, e = bareKey(path, i)
+			}
+			if e != nil {
+				return nil, ErrInvalidPath.WithValues(path, e.Error())
+			}
+			segments = append(segments, pathSegment{key: key, wildcard: !quoted && key == "*"})
+			expectKey = false
+		}
+	}
+	if len(segments) == 0 {
+		return nil, ErrInvalidPath.WithValues(path, "empty path")
+	}
+	return segments, nil
+}
+
+// closingBracket finds the bracket closing an index, skipping quoted keys
+func closingBracket(path string, start int) int {
+	if start < len(path) && (path[start] == '"' || path[start] == '\'') {
+		if _, end, e := unquote(path, start); e == nil {
+			start = end
+		}
+	}
+	if i := strings.IndexByte(path[start:], ']'); i >= 0 {
+		return start + i
+	}
+	return -1
+}
+
+// unquote reads a quoted key starting at the given position, returning the key and the position following it
+func unquote(path string, start int) (string, int, error) {
+	quote := path[start]
+	buffer := &bytes.Buffer{}
+	for i := start + 1; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			if i++; i == len(path) {
+				return "", 0, fmt.Errorf("unterminated escape")
+			}
+			buffer.WriteByte(path[i])
+		case quote:
+			return buffer.String(), i + 1, nil
+		default:
+			buffer.WriteByte(path[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quote")
+}
+
+// bareKey reads an unquoted key starting at the given position, returning the key and the position following it
+func bareKey(path string, start int) (string, int, error) {
+	buffer := &bytes.Buffer{}
+	i := start
+	for ; i < len(path) && path[i] != '.' && path[i] != '['; i++ {
+		if path[i] == '\\' {
+			if i++; i == len(path) {
+				return "", 0, fmt.Errorf("unterminated escape")
+			}
+		}
+		buffer.WriteByte(path[i])
+	}
+	return buffer.String(), i, nil
+}
+
+// quoteKey quotes a key if it can't be given bare in a path
+func quoteKey(key string) string {
+	if key != "" && key != "*" && !strings.ContainsAny(key, ".[]\"'\\") {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// joinPath renders path segments
+func joinPath(segments []pathSegment) string {
+	buffer := &bytes.Buffer{}
+	for i, segment := range segments {
+		if i > 0 && !segment.isIndex {
+			buffer.WriteByte('.')
+		}
+		buffer.WriteString(segment.String())
+	}
+	return buffer.String()
+}
+
+// walk follows the segments of a path from the given position
+func walk(value interface{}, path string, segments []pathSegment, position int) (interface{}, error) {
+	for i := position; i < len(segments); i++ {
+		if value == nil {
+			return nil, nil
+		}
+		segment := segments[i]
+		if segment.wildcard {
+			children, walkable := childrenOf(value)
+			if !walkable {
+				return nil, ErrPathIntoScalar.WithValues(path, joinPath(segments[:i]))
+			}
+			spread := hasWildcard(segments[i+1:])
+			matches := make([]interface{}, 0, len(children))
+			for _, child := range children {
+				match, e := walk(child, path, segments, i+1)
+				if e != nil || match == nil {
+					continue
+				}
+				if spread {
+					matches = append(matches, match.([]interface{})...)
+				} else {
+					matches = append(matches, match)
+				}
+			}
+			return matches, nil
+		}
+
+		child, walkable := childOf(value, segment)
+		if !walkable {
+			return nil, ErrPathIntoScalar.WithValues(path, joinPath(segments[:i]))
+		}
+		value = child
+	}
+	return value, nil
+}
+
+// childOf gets the child of an object or list identified by a segment. Returns false if the value is not an object or
+// list the segment can walk into.
+func childOf(value interface{}, segment pathSegment) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v[segment.name()], true
+	case map[interface{}]interface{}:
+		name := segment.name()
+		if child, found := v[name]; found {
+			return child, true
+		}
+		// yaml keys may not be strings
+		for key, child := range v {
+			if fmt.Sprint(key) == name {
+				return child, true
+			}
+		}
+		return nil, true
+	case []interface{}:
+		index := segment.index
+		if !segment.isIndex {
+			var e error
+			if index, e = strconv.Atoi(segment.key); e != nil {
+				return nil, false
+			}
+		}
+		if index < 0 || index >= len(v) {
+			return nil, true
+		}
+		return v[index], true
+	}
+	return nil, false
+}
+
+// childrenOf gets all the children of an object, sorted by key, or of a list, as selected by a wildcard
+func childrenOf(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		children := make([]interface{}, len(keys))
+		for i, key := range keys {
+			children[i] = v[key]
+		}
+		return children, true
+	case map[interface{}]interface{}:
+		keys := make([]interface{}, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		children := make([]interface{}, len(keys))
+		for i, key := range keys {
+			children[i] = v[key]
+		}
+		return children, true
+	}
+	return nil, false
+}
+
+func hasWildcard(segments []pathSegment) bool {
+	for _, segment := range segments {
+		if segment.wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// overridden gets a copy of an object or list found at the given path in which the values overridden at their own
+// paths (object keys as path.key and list elements as path[index]) are replaced. Values found by wildcard paths are
+// given as they are. Returns the value itself and false if none of its values is overridden.
+func overridden(path string, value interface{}, override func(path string) (interface{}, bool)) (interface{}, bool) {
+	if segments, e := parsePath(path); e != nil || hasWildcard(segments) {
+		return value, false
+	}
+	return overrideValues(path, value, override)
+}
+
+// overrideValues replaces the overridden values of an object or list, or the value itself if it's overridden
+func overrideValues(path string, value interface{}, override func(path string) (interface{}, bool)) (interface{}, bool) {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			var childChanged bool
+			result[key], childChanged = overrideValues(path+"."+quoteKey(key), child, override)
+			changed = changed || childChanged
+		}
+		if changed {
+			return result, true
+		}
+	case map[interface{}]interface{}:
+		result := make(map[interface{}]interface{}, len(v))
+		for key, child := range v {
+			var childChanged bool
+			result[key], childChanged = overrideValues(path+"."+quoteKey(fmt.Sprint(key)), child, override)
+			changed = changed || childChanged
+		}
+		if changed {
+			return result, true
+		}
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, child := range v {
+			var childChanged bool
+			result[i], childChanged = overrideValues(path+"["+strconv.Itoa(i)+"]", child, override)
+			changed = changed || childChanged
+		}
+		if changed {
+			return result, true
+		}
+	default:
+		if overriding, isOverridden := override(path); isOverridden {
+			return overriding, true
+		}
+	}
+	return value, false
+}
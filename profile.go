@@ -0,0 +1,135 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProfileOptions
+// Sets where the active profiles are given: a cml switch, or an environment variable if the switch is not set.
+// Either may be empty to disable it.
+type ProfileOptions struct {
+	CmlSwitch           string
+	EnvironmentVariable string
+}
+
+var defaultProfileOptions = ProfileOptions{
+	CmlSwitch:           "profile",
+	EnvironmentVariable: "APP_PROFILE",
+}
+
+// profileSelector holds where the active profiles of an environment are given
+type profileSelector struct {
+	options ProfileOptions
+	lock    sync.Mutex
+}
+
+// ConfigureProfiles
+// Sets where the active profiles of the default environment are given (see Environment.ConfigureProfiles).
+func ConfigureProfiles(options ProfileOptions) {
+	env.ConfigureProfiles(options)
+}
+
+// ConfigureProfiles
+// Sets where the active profiles of the environment are given, which may also be set when it's created (see
+// Settings). Configuration providers resolve the active profiles when they are loaded, so it should be called before
+// loading.
+func (en *Environment) ConfigureProfiles(options ProfileOptions) {
+	en.profiles.lock.Lock()
+	en.profiles.options = options
+	en.profiles.lock.Unlock()
+}
+
+// ActiveProfiles
+// Gets the active profiles of the default environment (see Environment.ActiveProfiles).
+func ActiveProfiles() []string {
+	return env.ActiveProfiles()
+}
+
+// ActiveProfiles
+// Gets the active profiles, in order. Several profiles may be given separated by commas (-profile base,prod). The
+// environment variable is read through the environment variables provider, with its naming and prefix.
+func (en *Environment) ActiveProfiles() []string {
+	return en.profiles.active(en.cmlProvider, en.envProvider)
+}
+
+// active gets the active profiles given in the command line parsed by the given provider, or in the environment
+// variable provided by the given environment variables provider
+func (ps *profileSelector) active(cml *cmlArgumentsProvider, environment *environmentVariablesProvider) []string {
+	ps.lock.Lock()
+	options := ps.options
+	ps.lock.Unlock()
+
+	var value string
+	if v := cml.Get(options.CmlSwitch, nil); options.CmlSwitch != "" && v != nil {
+		value = v.(string)
+	} else if options.EnvironmentVariable != "" {
+		value, _ = environment.Get(options.EnvironmentVariable, nil).(string)
+	}
+
+	var profiles []string
+	for _, profile := range strings.Split(value, ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// DefaultFor
+// Sets the default value of the variable when the given profile is active. When several active profiles have a
+// default, the one of the last profile is used.
+func (v *variable) DefaultFor(profile string, defaultValue interface{}) *variable {
+	if v.profileDefaults == nil {
+		v.profileDefaults = make(map[string]interface{})
+	}
+	v.profileDefaults[profile] = defaultValue
+	return v
+}
+
+// defaults gets the default value of the variable for the active profiles
+func (v *variable) defaults() interface{} {
+	if len(v.profileDefaults) > 0 {
+		profiles := v.environment.ActiveProfiles()
+		for i := len(profiles) - 1; i >= 0; i-- {
+			if defaultValue, found := v.profileDefaults[profiles[i]]; found {
+				return defaultValue
+			}
+		}
+	}
+	return v.defaultValue
+}
+
+// profileFile gets the name of the overlay of a configuration file for a profile (config.yml gives config-prod.yml)
+func profileFile(filename string, profile string) string {
+	extension := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, extension) + "-" + profile + extension
+}
+
+// configurationFiles gets the files of a configuration, which are the given file followed by the existing overlays
+// of the given profiles, and their latest modification time.
+func configurationFiles(filename string, profiles []string) ([]string, time.Time, error) {
+	stat, e := os.Stat(filename)
+	if e != nil {
+		return nil, time.Time{}, e
+	}
+	files := []string{filename}
+	timestamp := stat.ModTime()
+	for _, profile := range profiles {
+		overlay := profileFile(filename, profile)
+		if stat, e = os.Stat(overlay); e == nil {
+			files = append(files, overlay)
+			if stat.ModTime().After(timestamp) {
+				timestamp = stat.ModTime()
+			}
+		}
+	}
+	return files, timestamp, nil
+}
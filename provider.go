@@ -4,6 +4,8 @@
 
 package env
 
+import "fmt"
+
 // A Provider is a component that is able to extract values from a specific source, when present. They can
 // be registered in the env package as a source of values
 type Provider interface {
@@ -19,6 +21,29 @@ type Provider interface {
 	Refresh() (bool, error)
 }
 
+// NamedProvider
+// A Provider able to identify itself in errors, logs and diagnostics. Providers not implementing it are identified by
+// their type.
+type NamedProvider interface {
+	Provider
+
+	// Name gets a short name for the provider (json, env...)
+	Name() string
+
+	// Description describes what the provider currently provides from (like the resolved filename)
+	Description() string
+}
+
+// DependentProvider
+// A Provider which needs other providers to be loaded before itself (like configuration file providers getting their
+// filename from the command line).
+type DependentProvider interface {
+	Provider
+
+	// DependsOn gets the providers to be loaded before this one
+	DependsOn() []Provider
+}
+
 // Source of a variable identifies the provider where the value will come from and the variable configuration to extract
 // the value from the provider
 type Source interface {
@@ -28,3 +53,31 @@ type Source interface {
 	// be defined and processed by the provider
 	Config() interface{}
 }
+
+// sourceName
+// Gets a short human-readable name identifying the provider of a source, to be used in messages. A nil source is
+// identified as the default value.
+func sourceName(s Source) string {
+	if s == nil {
+		return "default"
+	}
+	return providerName(s.Provider())
+}
+
+// providerName
+// Gets a short human-readable name identifying a provider, to be used in messages.
+func providerName(p Provider) string {
+	if named, isNamed := p.(NamedProvider); isNamed {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", p)
+}
+
+// describeProvider
+// Gets the name of a provider followed by its description, if it has one.
+func describeProvider(p Provider) string {
+	if named, isNamed := p.(NamedProvider); isNamed && named.Description() != "" {
+		return named.Name() + " (" + named.Description() + ")"
+	}
+	return providerName(p)
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"strings"
+	"time"
+)
+
+// Scope
+// View of the variables of an environment under a prefix. Names given to a scope are relative to its prefix, so
+// Get("host") in the database scope resolves database.host through the normal chain of sources: the dot path in json
+// and yaml files, the full name or its kebab-case form for cml switches (database.maxConnections or
+// database.max-connections) and the full name or its environment form for environment variables (database.host or
+// DATABASE_HOST), unless the providers have their own naming strategies (see UseNaming). Names are only mapped when
+// read through the scope, or for variables added through it, reading from the environment itself is not affected.
+type Scope struct {
+	environment *Environment
+	prefix      string
+}
+
+// Sub
+// Gets the scope of the variables of the environment under the given prefix.
+func (en *Environment) Sub(prefix string) *Scope {
+	return &Scope{environment: en, prefix: strings.Trim(prefix, ".")}
+}
+
+// Sub
+// Gets the scope of the variables of the default environment under the given prefix.
+func Sub(prefix string) *Scope {
+	return env.Sub(prefix)
+}
+
+// name gets the full name of a variable of the scope. An empty name is the scope itself.
+func (s *Scope) name(name string) string {
+	if s.prefix == "" || name == "" {
+		return s.prefix + name
+	}
+	return s.prefix + "." + name
+}
+
+// defaultSources gets the default sources of the environment, in which the sources of environment variables and cml
+// switches map the names of variables if scoped (see Scope)
+func (en *Environment) defaultSources(scoped bool) []Source {
+	sources := en.DefaultSources()
+	if scoped {
+		for i, s := range sources {
+			sources[i] = scopedSource(s)
+		}
+	}
+	return sources
+}
+
+// scopedSource gets a copy of a source of environment variables or cml switches which maps the names of variables,
+// unless it gives an explicit name or maps them already. Other sources are given as they are.
+func scopedSource(s Source) Source {
+	switch source := s.(type) {
+	case *environmentVariablesSource:
+		if source.name == nil && !source.scoped {
+			scoped := *source
+			scoped.scoped = true
+			return &scoped
+		}
+	case *cmlArgumentsSource:
+		if source.name == nil && !source.scoped {
+			scoped := *source
+			scoped.scoped = true
+			return &scoped
+		}
+	}
+	return s
+}
+
+// scopedSources gets the sources of a variable mapping its names, nil if its sources don't map any other name
+func (v *variable) scopedSources() []Source {
+	sources := make([]Source, len(v.sources))
+	mapped := false
+	for i, s := range v.sources {
+		sources[i] = scopedSource(s.source)
+		mapped = mapped || sources[i] != s.source
+	}
+	if !mapped {
+		return nil
+	}
+	return sources
+}
+
+// resolveFrom resolves a variable through the given sources, which are not the variable ones, so its value is not
+// cached
+func (en *Environment) resolveFrom(v *variable, sources []Source, resolving []string) (interface{}, Source, error) {
+	resolving = append(append([]string{}, resolving...), v.name)
+	var value interface{}
+	var valueSource Source
+	for _, s := range sources {
+		if value = s.Provider().Get(v.name, s.Config()); value != nil {
+			valueSource = s
+			break
+		}
+	}
+	value, valueSource, _, e := en.process(v, value, valueSource, resolving)
+	return value, valueSource, e
+}
+
+// nested gets the prefix of the names of the scope variables, used as prefix for bound structs
+func (s *Scope) nested() string {
+	if s.prefix == "" {
+		return ""
+	}
+	return s.prefix + "."
+}
+
+// Prefix
+// Gets the prefix of the scope.
+func (s *Scope) Prefix() string {
+	return s.prefix
+}
+
+// Environment
+// Gets the environment of the scope.
+func (s *Scope) Environment() *Environment {
+	return s.environment
+}
+
+// Sub
+// Gets the scope of the variables under the given prefix, relative to this scope.
+func (s *Scope) Sub(prefix string) *Scope {
+	return s.environment.Sub(s.name(strings.Trim(prefix, ".")))
+}
+
+// Var
+// Creates a variable under the scope prefix to be added to the environment.
+func (s *Scope) Var(name string) *variable {
+	v := s.environment.Var(s.name(name))
+	v.scoped = true
+	return v
+}
+
+// Computed
+// Creates a computed variable under the scope prefix (see Environment.Computed). Dependencies are also relative to the
+// scope.
+func (s *Scope) Computed(name string, dependencies []string, compute func(values map[string]interface{}) interface{}) *variable {
+	names := make([]string, len(dependencies))
+	for i, dependency := range dependencies {
+		names[i] = s.name(dependency)
+	}
+	return s.environment.Computed(s.name(name), names, func(values map[string]interface{}) interface{} {
+		relative := make(map[string]interface{}, len(values))
+		for i, dependency := range dependencies {
+			relative[dependency] = values[names[i]]
+		}
+		return compute(relative)
+	})
+}
+
+// Get
+// Gets the value of a variable of the scope (see Environment.Get).
+func (s *Scope) Get(name string) interface{} {
+	return s.environment.get(s.name(name), true)
+}
+
+// Keys
+// Gets the names of the variables provided under the given prefix (see Environment.Keys), relative to the scope.
+func (s *Scope) Keys(prefix string) []string {
+	keys := s.environment.Keys(s.name(prefix))
+	if s.prefix == "" {
+		return keys
+	}
+	relative := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != s.prefix {
+			relative = append(relative, key[len(s.prefix)+1:])
+		}
+	}
+	return relative
+}
+
+// Explain
+// Explains how the value of a variable of the scope is resolved (see Environment.Explain).
+func (s *Scope) Explain(name string) Explanation {
+	return s.environment.explain(s.name(name), true)
+}
+
+// Bind
+// Binds the fields of a struct to variables of the scope (see Environment.Bind).
+func (s *Scope) Bind(target interface{}) error {
+	_, e := s.environment.bind(target, s.nested(), true)
+	return e
+}
+
+// Watch
+// Creates a Watcher of variables of the scope (see Environment.Watch).
+func (s *Scope) Watch(factory func() interface{}) (*Watcher, error) {
+	return s.environment.watch(factory, s.nested(), true)
+}
+
+// GetString
+// Gets the value of a variable of the scope as a string (see Environment.GetString).
+func (s *Scope) GetString(name string) (string, error) {
+	v, e := s.environment.getConverted(s.name(name), true, "string", asString)
+	if v == nil {
+		return "", e
+	}
+	return v.(string), nil
+}
+
+// GetInt
+// Gets the value of a variable of the scope as an int (see Environment.GetInt).
+func (s *Scope) GetInt(name string) (int, error) {
+	v, e := s.environment.getConverted(s.name(name), true, "int", asInt)
+	if v == nil {
+		return 0, e
+	}
+	return v.(int), nil
+}
+
+// GetInt64
+// Gets the value of a variable of the scope as an int64 (see Environment.GetInt64).
+func (s *Scope) GetInt64(name string) (int64, error) {
+	v, e := s.environment.getConverted(s.name(name), true, "int64", asInt64)
+	if v == nil {
+		return 0, e
+	}
+	return v.(int64), nil
+}
+
+// GetFloat
+// Gets the value of a variable of the scope as a float64 (see Environment.GetFloat).
+func (s *Scope) GetFloat(name string) (float64, error) {
+	v, e := s.environment.getConverted(s.name(name), true, "float64", asFloat)
+	if v == nil {
+		return 0, e
+	}
+	return v.(float64), nil
+}
+
+// GetBool
+// Gets the value of a variable of the scope as a bool (see Environment.GetBool).
+func (s *Scope) GetBool(name string) (bool, error) {
+	v, e := s.environment.getConvertedFrom(s.name(name), true, "bool", switchAsBool)
+	if v == nil {
+		return false, e
+	}
+	return v.(bool), nil
+}
+
+// GetDuration
+// Gets the value of a variable of the scope as a time.Duration (see Environment.GetDuration).
+func (s *Scope) GetDuration(name string) (time.Duration, error) {
+	v, e := s.environment.getConverted(s.name(name), true, "time.Duration", asDuration)
+	if v == nil {
+		return 0, e
+	}
+	return v.(time.Duration), nil
+}
+
+// GetStringSlice
+// Gets the value of a variable of the scope as a []string (see Environment.GetStringSlice).
+func (s *Scope) GetStringSlice(name string) ([]string, error) {
+	v, e := s.environment.getConverted(s.name(name), true, "[]string", asStringSlice)
+	if v == nil {
+		return nil, e
+	}
+	return v.([]string), nil
+}
+
+// GetStringMap
+// Gets the value of a variable of the scope as a map[string]interface{} (see Environment.GetStringMap).
+func (s *Scope) GetStringMap(name string) (map[string]interface{}, error) {
+	v, e := s.environment.getConverted(s.name(name), true, "map[string]interface{}", asStringMap)
+	if v == nil {
+		return nil, e
+	}
+	return v.(map[string]interface{}), nil
+}
+
+// GetSection
+// Gets a section of the configuration under the scope (see Environment.GetSection).
+func (s *Scope) GetSection(name string) map[string]interface{} {
+	return s.environment.getMap(s.name(name), MergeOptions{}, true)
+}
+
+// GetMap
+// Gets a section of the configuration under the scope merged with the given options (see Environment.GetMap).
+func (s *Scope) GetMap(name string, options MergeOptions) map[string]interface{} {
+	return s.environment.getMap(s.name(name), options, true)
+}
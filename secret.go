@@ -0,0 +1,125 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SecretValue
+// Wraps the value of a secret variable. Printing, formatting or marshalling it to json or yaml always gives a
+// redacted placeholder. The actual value is only available through Value.
+type SecretValue struct {
+	value interface{}
+}
+
+// Value
+// Gets the wrapped value.
+func (sv SecretValue) Value() interface{} {
+	return sv.value
+}
+
+func (sv SecretValue) String() string {
+	return redacted
+}
+
+func (sv SecretValue) GoString() string {
+	return redacted
+}
+
+// Format
+// Formats the secret as the redacted placeholder, whatever the verb used.
+func (sv SecretValue) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(redacted))
+}
+
+func (sv SecretValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redacted)
+}
+
+func (sv SecretValue) MarshalYAML() (interface{}, error) {
+	return redacted, nil
+}
+
+// unwrap gets the value wrapped by a SecretValue, or the given value if it's not a secret
+func unwrap(value interface{}) interface{} {
+	if sv, isSecret := value.(SecretValue); isSecret {
+		return sv.value
+	}
+	return value
+}
+
+// expose gets the value of a variable as it is given to the outside: wrapped in a SecretValue if the variable is a
+// secret.
+func (v *variable) expose(value interface{}) interface{} {
+	if v.isSecret() && value != nil {
+		return SecretValue{value: value}
+	}
+	return value
+}
+
+// mask replaces any occurrence of the given values in a text by the redacted placeholder
+func mask(text string, values ...interface{}) string {
+	for _, value := range values {
+		if value = unwrap(value); value == nil {
+			continue
+		}
+		if s, e := asString(value); e == nil && s.(string) != "" {
+			text = strings.ReplaceAll(text, s.(string), redacted)
+		}
+	}
+	return text
+}
+
+// maskError masks the given values in the message of an error
+func maskError(e error, values ...interface{}) error {
+	if e == nil {
+		return nil
+	}
+	if masked := mask(e.Error(), values...); masked != e.Error() {
+		return fmt.Errorf("%s", masked)
+	}
+	return e
+}
+
+// isSecret checks if the variable with the given name is a secret
+func (en *Environment) isSecret(name string) bool {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	v, found := en.variables[name]
+	return found && v.isSecret()
+}
+
+// referencesSecrets checks if any of the given names is a secret variable, or a variable referencing secret ones
+func (en *Environment) referencesSecrets(names []string) bool {
+	for _, name := range names {
+		if en.isSecret(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact masks in a text all the values known for secret variables of the environment
+func (en *Environment) redact(text string) string {
+	for _, v := range en.variablesSnapshot() {
+		if !v.isSecret() {
+			continue
+		}
+		v.mutex.Lock()
+		if v.cachedValue != nil {
+			text = mask(text, v.cachedValue.value)
+		}
+		for _, s := range v.sources {
+			if s.cachedValue != nil {
+				text = mask(text, s.cachedValue.value)
+			}
+		}
+		v.mutex.Unlock()
+	}
+	return text
+}
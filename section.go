@@ -0,0 +1,201 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+// ListStrategy
+// Sets how lists given by several sources are merged.
+type ListStrategy int
+
+const (
+	// ReplaceLists keeps the list of the source with the highest priority
+	ReplaceLists ListStrategy = iota
+	// AppendLists appends the elements of the lists of sources with higher priority to the ones with lower priority
+	AppendLists
+	// MergeListsByKey merges objects of the lists with the same value in their key field (see MergeOptions), appending
+	// the others
+	MergeListsByKey
+)
+
+// MergeOptions
+// Sets how sections given by several sources are merged.
+type MergeOptions struct {
+	Lists ListStrategy
+	// Key is the field identifying the objects of lists merged by key
+	Key string
+}
+
+// GetSection
+// Gets a section of the configuration deeply merged across all the sources of the variable (the default sources for
+// ad-hoc variables), replacing lists. See GetMap.
+func (en *Environment) GetSection(name string) map[string]interface{} {
+	return en.GetMap(name, MergeOptions{})
+}
+
+// GetMap
+// Gets a section of the configuration deeply merged across all the sources of the variable (the default sources for
+// ad-hoc variables). Objects are merged key by key, with the values of sources with higher priority replacing the
+// others, and lists are merged according to the given options. Sources without nested values (like cml switches or
+// environment variables) contribute with the names under the section (section.key) if they list them (see
+// KeyLister). Values are normalized to map[string]interface{} and given as provided. Returns nil if no source provides
+// the section.
+func (en *Environment) GetMap(name string, options MergeOptions) map[string]interface{} {
+	return en.getMap(name, options, false)
+}
+
+// getMap gets a section of the configuration merged with the given options, the sources mapping the names of the
+// variables if scoped (see Scope)
+func (en *Environment) getMap(name string, options MergeOptions, scoped bool) map[string]interface{} {
+	sources := en.DefaultSources()
+	en.lock.Lock()
+	v, found := en.variables[name]
+	en.lock.Unlock()
+	if found {
+		sources = make([]Source, len(v.sources))
+		for i, s := range v.sources {
+			sources[i] = s.source
+		}
+	}
+	if scoped {
+		for i, s := range sources {
+			sources[i] = scopedSource(s)
+		}
+	}
+
+	var merged interface{}
+	for i := len(sources) - 1; i >= 0; i-- {
+		if section := sectionOf(name, sources[i]); section != nil {
+			merged = mergeValues(merged, section, options)
+		}
+	}
+	section, _ := merged.(map[string]interface{})
+	return section
+}
+
+// sectionOf gets the section given by a source, normalized, or built from the names under the section if the source
+// doesn't provide it as an object.
+func sectionOf(name string, s Source) map[string]interface{} {
+	value := s.Provider().Get(name, s.Config())
+	if value != nil {
+		section, _ := normalize(value).(map[string]interface{})
+		return section
+	}
+	lister, isLister := s.Provider().(KeyLister)
+	if !isLister {
+		return nil
+	}
+	var section map[string]interface{}
+	for _, key := range lister.Keys(name) {
+		if key == name {
+			continue
+		}
+		// keys of the root section are already relative to it
+		relative := key
+		if name != "" {
+			relative = key[len(name)+1:]
+		}
+		segments, e := parsePath(relative)
+		if e != nil {
+			continue
+		}
+		path := make([]string, len(segments))
+		for i, segment := range segments {
+			path[i] = segment.name()
+		}
+		if section == nil {
+			section = make(map[string]interface{})
+		}
+		setPath(section, path, normalize(s.Provider().Get(key, nil)))
+	}
+	return section
+}
+
+// setPath sets a value in a nested object, creating the missing objects of the path
+func setPath(object map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		child, isObject := object[key].(map[string]interface{})
+		if !isObject {
+			child = make(map[string]interface{})
+			object[key] = child
+		}
+		object = child
+	}
+	object[path[len(path)-1]] = value
+}
+
+// mergeValues deeply merges an overlay into a configuration value. Objects are merged key by key, lists according to
+// the options and any other value of the overlay replaces the original value.
+func mergeValues(base interface{}, overlay interface{}, options MergeOptions) interface{} {
+	switch b := base.(type) {
+	case map[string]interface{}:
+		if o, isType := overlay.(map[string]interface{}); isType {
+			for key, value := range o {
+				b[key] = mergeValues(b[key], value, options)
+			}
+			return b
+		}
+	case map[interface{}]interface{}:
+		if o, isType := overlay.(map[interface{}]interface{}); isType {
+			for key, value := range o {
+				b[key] = mergeValues(b[key], value, options)
+			}
+			return b
+		}
+	case []interface{}:
+		if o, isType := overlay.([]interface{}); isType {
+			switch options.Lists {
+			case AppendLists:
+				return append(b, o...)
+			case MergeListsByKey:
+				return mergeByKey(b, o, options)
+			}
+		}
+	}
+	return overlay
+}
+
+// mergeByKey merges the objects of a list with the objects of the base list having the same key, appending the others
+func mergeByKey(base []interface{}, overlay []interface{}, options MergeOptions) []interface{} {
+	for _, element := range overlay {
+		merged := false
+		if key, hasKey := field(element, options.Key); hasKey {
+			for i, baseElement := range base {
+				if baseKey, baseHasKey := field(baseElement, options.Key); baseHasKey && equal(key, baseKey) {
+					base[i] = mergeValues(baseElement, element, options)
+					merged = true
+					break
+				}
+			}
+		}
+		if !merged {
+			base = append(base, element)
+		}
+	}
+	return base
+}
+
+// field gets the value of a field of an object
+func field(object interface{}, name string) (interface{}, bool) {
+	var value interface{}
+	switch o := object.(type) {
+	case map[string]interface{}:
+		value = o[name]
+	case map[interface{}]interface{}:
+		value = o[name]
+	}
+	return value, value != nil
+}
+
+// GetSection
+// Gets a section of the configuration of the default environment (see Environment.GetSection).
+func GetSection(name string) map[string]interface{} {
+	return env.GetSection(name)
+}
+
+// GetMap
+// Gets a section of the configuration of the default environment merged with the given options (see
+// Environment.GetMap).
+func GetMap(name string, options MergeOptions) map[string]interface{} {
+	return env.GetMap(name, options)
+}
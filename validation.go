@@ -0,0 +1,309 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gomatbase/go-error"
+)
+
+const (
+	ErrInvalidValue            = err.ErrorF("Invalid value %v for variable %s (from %s): %v")
+	ErrConditionallyRequired   = err.ErrorF("Property %s not provided! Required %s %s is %v")
+	ErrMissingAlternatives     = err.ErrorF("One of %s must be provided!")
+	ErrConflictingAlternatives = err.ErrorF("Only one of %s may be provided, but %s are")
+	ErrIncompleteGroup         = err.ErrorF("Properties %s must be provided together, missing %s")
+)
+
+// Validator
+// Checks the value of a variable, returning an error describing why it is not valid. Validators are only called for
+// provided values, missing values are checked through Required.
+type Validator func(value interface{}) error
+
+// Validate
+// Adds validators to the variable, which are checked by Validate.
+func (v *variable) Validate(validators ...Validator) *variable {
+	v.validators = append(v.validators, validators...)
+	return v
+}
+
+// RequiredIf
+// Requires the variable when the variable with the given name has the given value (matched like OneOf does).
+func (v *variable) RequiredIf(name string, value interface{}) *variable {
+	v.conditions = append(v.conditions, requirement{name: name, value: value, unless: false})
+	return v
+}
+
+// RequiredUnless
+// Requires the variable unless the variable with the given name has the given value (matched like OneOf does).
+func (v *variable) RequiredUnless(name string, value interface{}) *variable {
+	v.conditions = append(v.conditions, requirement{name: name, value: value, unless: true})
+	return v
+}
+
+// requirement is a condition making a variable required depending on the value of another variable
+type requirement struct {
+	name   string
+	value  interface{}
+	unless bool
+}
+
+// unmet checks the conditional requirements of the variable, returning an error for the first one making a missing
+// variable required.
+func (v *variable) unmet(en *Environment) error {
+	for _, condition := range v.conditions {
+		conditionValue, _ := en.lookup(condition.name)
+		matches := conditionValue != nil && OneOf(condition.value)(conditionValue) == nil
+		if matches != condition.unless {
+			keyword := "if"
+			if condition.unless {
+				keyword = "unless"
+			}
+			return ErrConditionallyRequired.WithValues(v.name, keyword, condition.name, condition.value)
+		}
+	}
+	return nil
+}
+
+// validate checks the current value of the variable against its validators
+func (v *variable) validate(value interface{}, s Source) []error {
+	var errors []error
+	for _, validator := range v.validators {
+		if e := validator(value); e != nil {
+			var shownValue interface{} = fmt.Sprintf("%q", fmt.Sprint(value))
+			if v.isSecret() {
+				shownValue = redacted
+				e = maskError(e, value)
+			}
+			errors = append(errors, ErrInvalidValue.WithValues(shownValue, v.name, sourceName(s), e))
+		}
+	}
+	return errors
+}
+
+// Min
+// Validates that a numeric value is not lower than min.
+func Min(min float64) Validator {
+	return func(value interface{}) error {
+		f, e := asFloat(value)
+		if e != nil {
+			return e
+		}
+		if f.(float64) < min {
+			return fmt.Errorf("must not be lower than %v", min)
+		}
+		return nil
+	}
+}
+
+// Max
+// Validates that a numeric value is not greater than max.
+func Max(max float64) Validator {
+	return func(value interface{}) error {
+		f, e := asFloat(value)
+		if e != nil {
+			return e
+		}
+		if f.(float64) > max {
+			return fmt.Errorf("must not be greater than %v", max)
+		}
+		return nil
+	}
+}
+
+// OneOf
+// Validates that the value is one of the given values. Values are also matched by their string representation, so
+// the string "1" given by an environment variable matches 1.
+func OneOf(values ...interface{}) Validator {
+	return func(value interface{}) error {
+		for _, v := range values {
+			if equal(value, v) {
+				return nil
+			}
+			if s1, e := asString(value); e == nil {
+				if s2, e := asString(v); e == nil && s1 == s2 {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("must be one of %v", values)
+	}
+}
+
+// Matches
+// Validates that the string representation of the value matches the regular expression. Panics if the expression
+// cannot be compiled.
+func Matches(expression string) Validator {
+	re := regexp.MustCompile(expression)
+	return func(value interface{}) error {
+		s, e := asString(value)
+		if e != nil {
+			return e
+		}
+		if !re.MatchString(s.(string)) {
+			return fmt.Errorf("must match %s", expression)
+		}
+		return nil
+	}
+}
+
+// NonEmpty
+// Validates that a string is not blank, or that a list or object is not empty.
+func NonEmpty() Validator {
+	return func(value interface{}) error {
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.String:
+			if strings.TrimSpace(rv.String()) == "" {
+				return fmt.Errorf("must not be empty")
+			}
+		case reflect.Slice, reflect.Map, reflect.Array:
+			if rv.Len() == 0 {
+				return fmt.Errorf("must not be empty")
+			}
+		}
+		return nil
+	}
+}
+
+// IsURL
+// Validates that the value is an absolute URL, with a scheme and a host.
+func IsURL() Validator {
+	return func(value interface{}) error {
+		s, e := asString(value)
+		if e != nil {
+			return e
+		}
+		u, e := url.Parse(s.(string))
+		if e != nil {
+			return e
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("must be an absolute url")
+		}
+		return nil
+	}
+}
+
+// IsPort
+// Validates that the value is a valid TCP/UDP port number (1-65535).
+func IsPort() Validator {
+	return func(value interface{}) error {
+		i, e := asInt(value)
+		if e != nil {
+			return e
+		}
+		if i.(int) < 1 || i.(int) > 65535 {
+			return fmt.Errorf("must be a port number between 1 and 65535")
+		}
+		return nil
+	}
+}
+
+// FileExists
+// Validates that the value is the path of an existing file or directory.
+func FileExists() Validator {
+	return func(value interface{}) error {
+		s, e := asString(value)
+		if e != nil {
+			return e
+		}
+		if _, e = os.Stat(s.(string)); e != nil {
+			return fmt.Errorf("must be an existing file")
+		}
+		return nil
+	}
+}
+
+// ValidateWith
+// Adds a validation rule to the environment, checked by Validate with access to the whole environment.
+func (en *Environment) ValidateWith(rule func(environment *Environment) error) {
+	en.addRule(func(environment *Environment) []error {
+		if e := rule(environment); e != nil {
+			return []error{e}
+		}
+		return nil
+	})
+}
+
+func (en *Environment) addRule(rule func(environment *Environment) []error) {
+	en.lock.Lock()
+	en.rules = append(en.rules, rule)
+	en.lock.Unlock()
+}
+
+// ExactlyOneOf
+// Adds a validation rule requiring exactly one of the given groups of variables to be provided. A group is provided
+// when all its variables are, and a partially provided group is a failure.
+//
+//	ExactlyOneOf([]string{"auth.token"}, []string{"auth.user", "auth.password"})
+func (en *Environment) ExactlyOneOf(groups ...[]string) {
+	en.addRule(alternatives(groups, true))
+}
+
+// AtMostOneOf
+// Adds a validation rule allowing at most one of the given groups of variables to be provided (see ExactlyOneOf).
+func (en *Environment) AtMostOneOf(groups ...[]string) {
+	en.addRule(alternatives(groups, false))
+}
+
+// alternatives creates the validation rule for mutually exclusive groups of variables
+func alternatives(groups [][]string, required bool) func(environment *Environment) []error {
+	return func(environment *Environment) []error {
+		var errors []error
+		var provided []string
+		for _, group := range groups {
+			var missing []string
+			for _, name := range group {
+				if value, _ := environment.lookup(name); value == nil {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) == 0 {
+				provided = append(provided, strings.Join(group, "+"))
+			} else if len(missing) < len(group) {
+				errors = append(errors, ErrIncompleteGroup.WithValues(strings.Join(group, ", "), strings.Join(missing, ", ")))
+			}
+		}
+		if len(provided) > 1 {
+			errors = append(errors, ErrConflictingAlternatives.WithValues(describeGroups(groups), strings.Join(provided, ", ")))
+		} else if len(provided) == 0 && required && len(errors) == 0 {
+			errors = append(errors, ErrMissingAlternatives.WithValues(describeGroups(groups)))
+		}
+		return errors
+	}
+}
+
+func describeGroups(groups [][]string) string {
+	descriptions := make([]string, len(groups))
+	for i, group := range groups {
+		descriptions[i] = strings.Join(group, "+")
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// ValidateWith
+// Adds a validation rule to the default environment (see Environment.ValidateWith).
+func ValidateWith(rule func(environment *Environment) error) {
+	env.ValidateWith(rule)
+}
+
+// ExactlyOneOf
+// Adds an exactly one of validation rule to the default environment (see Environment.ExactlyOneOf).
+func ExactlyOneOf(groups ...[]string) {
+	env.ExactlyOneOf(groups...)
+}
+
+// AtMostOneOf
+// Adds an at most one of validation rule to the default environment (see Environment.AtMostOneOf).
+func AtMostOneOf(groups ...[]string) {
+	env.AtMostOneOf(groups...)
+}
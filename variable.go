@@ -4,22 +4,42 @@
 
 package env
 
-import "sync"
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
 
 type valuePlaceholder struct {
-	value interface{}
+	value  interface{}
+	source Source
 }
 
 type variable struct {
-	name         string
-	required     bool
-	defaultValue interface{}
-	cachedValue  *valuePlaceholder
-	sources      []*source
-	chain        []Provider
-	converter    func(value interface{}) interface{}
-	listener     func(oldValue interface{}, newValue interface{})
-	mutex        sync.Mutex
+	name            string
+	required        bool
+	secret          bool
+	defaultValue    interface{}
+	profileDefaults map[string]interface{}
+	cachedValue     *valuePlaceholder
+	sources         []*source
+	chain           []Provider
+	converter       func(value interface{}, s Source) (interface{}, error)
+	conversionError error
+	references      []string
+	dependencies    []string
+	compute         func(values map[string]interface{}) interface{}
+	validators      []Validator
+	conditions      []requirement
+	listener        func(oldValue interface{}, newValue interface{})
+	environment     *Environment
+	// scoped variables are added through a scope, their default sources map their names (see Scope)
+	scoped bool
+	mutex  sync.Mutex
+
+	// secretReferences is 1 if the current value references values of secret variables. It's accessed atomically,
+	// as it's checked with the variable locked or not.
+	secretReferences int32
 }
 
 type source struct {
@@ -27,8 +47,10 @@ type source struct {
 	cachedValue *valuePlaceholder
 }
 
+// Var
+// Creates a variable to be added to the default environment.
 func Var(name string) *variable {
-	return &variable{name: name, sources: make([]*source, 0)}
+	return env.Var(name)
 }
 
 func (v *variable) Default(defaultValue interface{}) *variable {
@@ -49,11 +71,129 @@ func (v *variable) Required() *variable {
 	return v
 }
 
+// Secret
+// Flags the variable as holding a secret. Its values are given wrapped in a SecretValue by Get and to listeners, and
+// are masked in error messages, logs, explanations and configuration dumps. Variables whose values interpolate or are
+// computed from secret variables are handled as secrets too.
+func (v *variable) Secret() *variable {
+	v.secret = true
+	return v
+}
+
+// isSecret checks if the variable is a secret or if its current value references values of secret variables
+func (v *variable) isSecret() bool {
+	return v.secret || atomic.LoadInt32(&v.secretReferences) == 1
+}
+
+// referenceSecrets sets if the current value of the variable references values of secret variables
+func (v *variable) referenceSecrets(secret bool) {
+	var flag int32
+	if secret {
+		flag = 1
+	}
+	atomic.StoreInt32(&v.secretReferences, flag)
+}
+
+// ConvertWith
+// Sets the converter applied to values provided by the variable sources. A value failing conversion is discarded and
+// the failure is reported by Validate and SyncedRefresh.
+func (v *variable) ConvertWith(converter func(value interface{}) (interface{}, error)) *variable {
+	v.converter = func(value interface{}, _ Source) (interface{}, error) {
+		return converter(value)
+	}
+	return v
+}
+
+// AsString
+// Converts the variable values to string.
+func (v *variable) AsString() *variable {
+	return v.ConvertWith(asString)
+}
+
+// AsInt
+// Converts the variable values to int.
+func (v *variable) AsInt() *variable {
+	return v.ConvertWith(asInt)
+}
+
+// AsInt64
+// Converts the variable values to int64.
+func (v *variable) AsInt64() *variable {
+	return v.ConvertWith(asInt64)
+}
+
+// AsFloat
+// Converts the variable values to float64.
+func (v *variable) AsFloat() *variable {
+	return v.ConvertWith(asFloat)
+}
+
+// AsBool
+// Converts the variable values to bool. A command line switch given without a value is taken as true.
+func (v *variable) AsBool() *variable {
+	v.converter = switchAsBool
+	return v
+}
+
+// AsDuration
+// Converts the variable values to time.Duration.
+func (v *variable) AsDuration() *variable {
+	return v.ConvertWith(asDuration)
+}
+
+// AsStringSlice
+// Converts the variable values to []string.
+func (v *variable) AsStringSlice() *variable {
+	return v.ConvertWith(asStringSlice)
+}
+
+// AsStringMap
+// Converts the variable values to map[string]interface{}.
+func (v *variable) AsStringMap() *variable {
+	return v.ConvertWith(asStringMap)
+}
+
 func (v *variable) ListeningWith(listener func(oldValue interface{}, newValue interface{})) *variable {
 	v.listener = listener
 	return v
 }
 
 func (v *variable) Add() error {
-	return addVar(v)
+	return v.environment.addVar(v)
+}
+
+// convert applies the variable converter, if any, to a value provided by the given source
+func (v *variable) convert(value interface{}, s Source) (interface{}, error) {
+	if value == nil || v.converter == nil {
+		return value, nil
+	}
+	converted, e := v.converter(value, s)
+	if e != nil {
+		if v.isSecret() {
+			e = maskError(e, value)
+		}
+		return nil, ErrConverterFailure.WithValues(v.name, sourceName(s), e)
+	}
+	return converted, nil
+}
+
+// current gets the value given by the source of the current value of the variable, before being processed
+func (v *variable) current() (interface{}, Source) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if v.cachedValue == nil {
+		return nil, nil
+	}
+	for _, s := range v.sources {
+		// a variable holding its default value may have failed processing the value of its first source
+		if s.cachedValue != nil && (s.source == v.cachedValue.source || v.cachedValue.source == nil && s.cachedValue.value != nil) {
+			return s.cachedValue.value, s.source
+		}
+	}
+	return nil, nil
+}
+
+// equal compares values deeply, as values provided by json or yaml sources may be objects or lists
+func equal(v1 interface{}, v2 interface{}) bool {
+	return reflect.DeepEqual(v1, v2)
 }
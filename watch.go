@@ -0,0 +1,111 @@
+// Copyright 2026 GOM. All rights reserved.
+// Since 17/10/2026 By GOM
+// Licensed under MIT License
+
+package env
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gomatbase/go-error"
+)
+
+// Watcher
+// Handle holding a struct bound to a set of variables (see Bind), which is replaced by a freshly populated struct
+// whenever a refresh changes any of those variables.
+type Watcher struct {
+	environment *Environment
+	factory     func() interface{}
+	prefix      string
+	scoped      bool
+	names       []string
+	current     atomic.Value
+	listener    func(oldValue interface{}, newValue interface{})
+	mutex       sync.Mutex
+}
+
+// Watch
+// Creates a Watcher for the structs created by factory, which must return a new pointer to a struct on each call.
+// The struct fields are bound to variables in the same way as Bind does, and the returned error aggregates the
+// failures of the initial binding. On refresh the struct is only replaced if all its fields are successfully set.
+func (en *Environment) Watch(factory func() interface{}) (*Watcher, error) {
+	return en.watch(factory, "", false)
+}
+
+// watch creates a Watcher binding variable names under the given prefix, mapped if they are variables of a scope
+func (en *Environment) watch(factory func() interface{}, prefix string, scoped bool) (*Watcher, error) {
+	target := factory()
+	names, e := en.bind(target, prefix, scoped)
+	if e == ErrInvalidBindTarget {
+		return nil, e
+	}
+
+	w := &Watcher{
+		environment: en,
+		factory:     factory,
+		prefix:      prefix,
+		scoped:      scoped,
+		names:       names,
+	}
+	w.current.Store(target)
+
+	en.lock.Lock()
+	en.watchers = append(en.watchers, w)
+	en.lock.Unlock()
+
+	return w, e
+}
+
+// Watch
+// Creates a Watcher of variables of the default environment (see Environment.Watch).
+func Watch(factory func() interface{}) (*Watcher, error) {
+	return env.Watch(factory)
+}
+
+// Get
+// Gets the current struct. The returned struct is never changed by the watcher and should be treated as read-only.
+func (w *Watcher) Get() interface{} {
+	return w.current.Load()
+}
+
+// ListeningWith
+// Sets the listener called with the old and the new struct whenever the struct is replaced.
+func (w *Watcher) ListeningWith(listener func(oldValue interface{}, newValue interface{})) *Watcher {
+	w.mutex.Lock()
+	w.listener = listener
+	w.mutex.Unlock()
+	return w
+}
+
+// watches checks if any of the given variable names is bound to the watcher
+func (w *Watcher) watches(names map[string]bool) bool {
+	for _, name := range w.names {
+		if names[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// refresh populates a new struct and swaps it with the current one, notifying the listener
+func (w *Watcher) refresh() error {
+	target := w.factory()
+	errors := err.Errors()
+	w.environment.bindStruct(reflect.ValueOf(target).Elem(), w.prefix, w.scoped, errors)
+	if errors.Count() > 0 {
+		return errors
+	}
+
+	w.mutex.Lock()
+	oldValue := w.current.Load()
+	w.current.Store(target)
+	listener := w.listener
+	w.mutex.Unlock()
+
+	if listener != nil {
+		listener(oldValue, target)
+	}
+	return nil
+}